@@ -0,0 +1,333 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/chandhuDev/JobLoop/internal/config"
+	dbService "github.com/chandhuDev/JobLoop/internal/database"
+	"github.com/chandhuDev/JobLoop/internal/interfaces"
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	models "github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/repository"
+	service "github.com/chandhuDev/JobLoop/internal/service"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+/* ================= COMMANDS ================= */
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(args []string) int
+}
+
+// commands is filled in init since the help command lists it.
+var commands []command
+
+func init() {
+	commands = []command{
+		{"crawl", "", "discover seed companies and scrape jobs and testimonials (default)", runCrawl},
+		{"scrape-company", "<url>", "run only the job scraper on one company", runScrapeCompany},
+		{"testimonials", "<url>", "find the customer logos on one company's site and name them", runTestimonials},
+		{"resolve", "<name>", "look up a company's website through the resolver chain", runResolve},
+		{"reclassify", "", "re-run the noise and engineering filters over stored jobs", runReclassify},
+		{"retry-failed", "", "scrape jobs again for companies whose job scrape failed", runRetryFailed},
+		{"stats", "", "print table counts, checkpointed work and today's LLM spend", runStats},
+		{"migrate", "", "create or update the database schema", runMigrate},
+		{"merge", "", "merge duplicate companies", runMerge},
+	}
+}
+
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: scraper <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-28s %s\n", strings.TrimSpace(c.name+" "+c.args), c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Every command accepts --config, --profile, --dry-run and --output (text|json).")
+	fmt.Fprintln(w, "Run 'scraper <command> -h' for the flags of one command.")
+}
+
+/* ================= FLAGS ================= */
+
+// commonFlags are shared by every subcommand.
+type commonFlags struct {
+	config  string
+	profile string
+	dryRun  bool
+	output  string
+}
+
+func newFlagSet(name string, args string, common *commonFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(&common.config, "config", os.Getenv("SCRAPER_CONFIG"), "config file (default "+config.DefaultPath+")")
+	fs.StringVar(&common.profile, "profile", os.Getenv("SCRAPER_PROFILE"), "config profile, e.g. dev, nightly or full")
+	fs.BoolVar(&common.dryRun, "dry-run", false, "report what would happen without writing to the database")
+	fs.StringVar(&common.output, "output", outputText, "output format: text or json")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: scraper %s [flags] %s\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseCommand parses flags placed before or after the positional arguments
+// and checks their count. It returns false after printing the problem.
+func parseCommand(fs *flag.FlagSet, common *commonFlags, args []string, positional int) ([]string, bool) {
+	var rest []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, false
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		rest = append(rest, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(rest) != positional {
+		fmt.Fprintf(fs.Output(), "%s: expected %d argument(s), got %d\n", fs.Name(), positional, len(rest))
+		fs.Usage()
+		return nil, false
+	}
+	if common.output != outputText && common.output != outputJSON {
+		fmt.Fprintf(fs.Output(), "%s: --output must be %q or %q\n", fs.Name(), outputText, outputJSON)
+		return nil, false
+	}
+	return rest, true
+}
+
+/* ================= SETUP ================= */
+
+// loadConfig loads and exports the scraper config so the services see it.
+func loadConfig(common commonFlags) (*config.Config, bool) {
+	cfg, err := config.Load(common.config, common.profile)
+	if err != nil {
+		logger.Error().Err(err).Msg("error loading scraper config")
+		return nil, false
+	}
+	cfg.Export()
+	logger.Info().Str("path", cfg.Path).Str("profile", cfg.Profile).Msg("Loaded scraper config")
+	return cfg, true
+}
+
+func requireEnv(names ...string) bool {
+	for _, env := range names {
+		if os.Getenv(env) == "" {
+			logger.Error().Str("var", env).Msg("required env variable not set")
+			return false
+		}
+	}
+	return true
+}
+
+// openDatabase connects and, unless migrate is false, brings the schema up
+// to date first.
+func openDatabase(migrate bool) (*dbService.DatabaseService, bool) {
+	if !requireEnv("DB_USER", "DB_PASSWORD", "DB_HOST") {
+		return nil, false
+	}
+
+	dbInstance := dbService.ConnectDatabase()
+	if dbInstance == nil || dbInstance.DB == nil {
+		logger.Error().Msg("failed to connect to database")
+		return nil, false
+	}
+	dbSvc := &dbService.DatabaseService{DB: dbInstance}
+
+	if migrate {
+		if err := dbSvc.CreateSchema(); err != nil {
+			logger.Error().Err(err).Msg("error creating schema")
+			dbSvc.Close()
+			return nil, false
+		}
+	}
+	return dbSvc, true
+}
+
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+}
+
+func browserOptions() models.Options {
+	return models.Options{
+		Headless:     true,
+		WindowWidth:  1920,
+		WindowHeight: 1080,
+		Mode:         os.Getenv("SCRAPER_MODE"),
+		ArchiveDir:   os.Getenv("SCRAPER_ARCHIVE_DIR"),
+	}
+}
+
+// companySession is the part of the crawl pipeline needed to work on single
+// companies from the command line. In dry-run mode LLM usage is not recorded.
+type companySession struct {
+	db      *dbService.DatabaseService
+	browser *service.BrowserService
+	scraper *interfaces.ScraperClient
+	vision  *service.VisionWrapper
+	dryRun  bool
+}
+
+func openCompanySession(ctx context.Context, common commonFlags, cfg *config.Config) (*companySession, bool) {
+	if !requireEnv("ANTHROPIC_API_KEY") {
+		return nil, false
+	}
+
+	dbSvc, ok := openDatabase(!common.dryRun)
+	if !ok {
+		return nil, false
+	}
+
+	browserInstance, err := service.CreateNewBrowser(browserOptions(), ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("error creating browser")
+		dbSvc.Close()
+		return nil, false
+	}
+
+	runID := service.NewRunID()
+	usageDB := dbSvc.GetDB()
+	if common.dryRun {
+		usageDB = nil
+	}
+	usage := service.NewUsageTracker(usageDB, runID)
+
+	// Names found here are reported, not fed back into a crawl
+	namesChannel := service.CreateNamesChannel(cfg.Concurrency.NamesBuffer)
+	go func() {
+		for range namesChannel.ReturnNamesChan().NamesChan {
+		}
+	}()
+
+	// A batch can take hours to end, far too long for one site, so these
+	// commands use the realtime provider unless the fake one was asked for
+	if provider := strings.TrimSpace(os.Getenv("VISION_PROVIDER")); provider == "" || provider == "batch" {
+		os.Setenv("VISION_PROVIDER", "realtime")
+	}
+
+	visionInstance := service.CreateVisionInstance()
+	visionProvider, err := service.NewVisionProvider(visionInstance, nil, namesChannel.ReturnNamesChan(), usage)
+	if err != nil {
+		logger.Error().Err(err).Msg("error creating vision provider")
+		browserInstance.Close()
+		dbSvc.Close()
+		return nil, false
+	}
+
+	scraperClient := service.SetUpScraperClient(
+		browserInstance,
+		visionInstance,
+		nil,
+		dbSvc,
+		namesChannel.ReturnNamesChan(),
+		&service.ArtifactService{Artifact: service.NewArtifactRecorder(runID)},
+		runID,
+		usage,
+	)
+
+	return &companySession{
+		db:      dbSvc,
+		browser: browserInstance,
+		scraper: scraperClient,
+		vision: &service.VisionWrapper{
+			Vision:   service.SetUpVision(visionInstance, ctx, namesChannel.ReturnNamesChan()),
+			Provider: visionProvider,
+		},
+		dryRun: common.dryRun,
+	}, true
+}
+
+func (s *companySession) Close() {
+	s.browser.Close()
+	s.db.Close()
+}
+
+// company finds the stored company for a URL by its canonical domain. Unknown
+// companies are created, except in dry-run mode where they get ID 0 and no
+// scrape failures are recorded for them.
+func (s *companySession) company(ctx context.Context, rawURL string, name string) (models.SeedCompanyResult, error) {
+	siteURL, domain, _ := service.CanonicalCompanyURL(ctx, rawURL)
+	if siteURL == "" {
+		return models.SeedCompanyResult{}, fmt.Errorf("invalid company URL %q", rawURL)
+	}
+
+	existing, err := repository.FindSeedCompanyByDomain(s.db.GetDB().WithContext(ctx), domain)
+	if err != nil {
+		return models.SeedCompanyResult{}, err
+	}
+	if existing != nil {
+		if name == "" {
+			name = existing.CompanyName
+		}
+		id := existing.ID
+		if s.dryRun {
+			id = 0
+		}
+		return models.SeedCompanyResult{CompanyName: name, CompanyURL: existing.CompanyURL, SeedCompanyId: id}, nil
+	}
+
+	if name == "" {
+		name = nameFromDomain(domain)
+	}
+	if s.dryRun {
+		return models.SeedCompanyResult{CompanyName: name, CompanyURL: siteURL}, nil
+	}
+
+	id, companyURL := service.CreateSeedCompanyRepo(ctx, name, siteURL, -1, *s.scraper)
+	return models.SeedCompanyResult{CompanyName: name, CompanyURL: companyURL, SeedCompanyId: id}, nil
+}
+
+// nameFromDomain turns "acme-labs.io" into "Acme-labs", and
+// "sites.google.com/view/acme" into "Acme", for companies seen for the first
+// time.
+func nameFromDomain(domain string) string {
+	label := domain
+	if idx := strings.LastIndex(label, "/"); idx >= 0 {
+		label = label[idx+1:]
+	} else if idx := strings.Index(label, "."); idx > 0 {
+		label = label[:idx]
+	}
+	if label == "" {
+		return domain
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+/* ================= OUTPUT ================= */
+
+// emit writes v as JSON, or calls text to print it for people.
+func emit(common commonFlags, v interface{}, text func(w io.Writer)) {
+	if common.output == outputJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(v)
+		return
+	}
+	text(os.Stdout)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	models "github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/repository"
+	service "github.com/chandhuDev/JobLoop/internal/service"
+)
+
+type jobRow struct {
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Location string `json:"location,omitempty"`
+	Team     string `json:"team,omitempty"`
+	Source   string `json:"source"`
+}
+
+type companyJobs struct {
+	Company string   `json:"company"`
+	URL     string   `json:"url"`
+	ID      uint     `json:"id,omitempty"`
+	Jobs    []jobRow `json:"jobs"`
+	Error   string   `json:"error,omitempty"`
+	Saved   bool     `json:"saved"`
+}
+
+type companyTestimonials struct {
+	Company    string   `json:"company"`
+	URL        string   `json:"url"`
+	ID         uint     `json:"id,omitempty"`
+	Images     int      `json:"images"`
+	Names      []string `json:"names"`
+	Unresolved []string `json:"unresolved,omitempty"`
+	Saved      bool     `json:"saved"`
+}
+
+// runScrapeCompany runs only the job scraper on one company and stores what
+// it finds unless --dry-run is set.
+func runScrapeCompany(args []string) int {
+	var common commonFlags
+	fs := newFlagSet("scrape-company", "<url>", &common)
+	name := fs.String("name", "", "company name, when the company is not stored yet")
+	rest, ok := parseCommand(fs, &common, args, 1)
+	if !ok {
+		return 2
+	}
+
+	cfg, ok := loadConfig(common)
+	if !ok {
+		return 1
+	}
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	session, ok := openCompanySession(ctx, common, cfg)
+	if !ok {
+		return 1
+	}
+	defer session.Close()
+
+	company, err := session.company(ctx, rest[0], *name)
+	if err != nil {
+		logger.Error().Err(err).Msg("error finding company")
+		return 1
+	}
+
+	result := scrapeCompanyJobs(ctx, session, company)
+	emit(common, result, func(w io.Writer) { printCompanyJobs(w, result) })
+	if result.Error != "" {
+		return 1
+	}
+	return 0
+}
+
+// scrapeCompanyJobs scrapes and, outside dry-run, upserts one company's jobs.
+func scrapeCompanyJobs(ctx context.Context, session *companySession, company models.SeedCompanyResult) companyJobs {
+	result := companyJobs{Company: company.CompanyName, URL: company.CompanyURL, ID: company.SeedCompanyId, Jobs: []jobRow{}}
+
+	jobs, err := service.ScrapeJobs(ctx, session.scraper, company)
+	for _, job := range jobs {
+		result.Jobs = append(result.Jobs, jobRow{Title: job.Text, URL: job.URL, Location: job.Location, Team: job.Team, Source: job.Source})
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	if session.dryRun || len(jobs) == 0 {
+		return result
+	}
+	if err := repository.UpsertJob(session.db.GetDB().WithContext(ctx), company.SeedCompanyId, jobs); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Saved = true
+	return result
+}
+
+func printCompanyJobs(w io.Writer, result companyJobs) {
+	fmt.Fprintf(w, "%s (%s)\n", result.Company, result.URL)
+	if result.Error != "" {
+		fmt.Fprintf(w, "  error: %s\n", result.Error)
+	}
+	for _, job := range result.Jobs {
+		fmt.Fprintf(w, "  %-50s %-20s %-10s %s\n", job.Title, job.Location, job.Source, job.URL)
+	}
+	fmt.Fprintf(w, "  %d job(s)", len(result.Jobs))
+	if result.Saved {
+		fmt.Fprint(w, ", saved")
+	}
+	fmt.Fprintln(w)
+}
+
+// runTestimonials finds the customer logos on one company's site and names
+// them. With --dry-run only the names readable without OCR are reported.
+func runTestimonials(args []string) int {
+	var common commonFlags
+	fs := newFlagSet("testimonials", "<url>", &common)
+	name := fs.String("name", "", "company name, when the company is not stored yet")
+	rest, ok := parseCommand(fs, &common, args, 1)
+	if !ok {
+		return 2
+	}
+
+	cfg, ok := loadConfig(common)
+	if !ok {
+		return 1
+	}
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	session, ok := openCompanySession(ctx, common, cfg)
+	if !ok {
+		return 1
+	}
+	defer session.Close()
+
+	company, err := session.company(ctx, rest[0], *name)
+	if err != nil {
+		logger.Error().Err(err).Msg("error finding company")
+		return 1
+	}
+
+	testimonial := service.TestimonialService{Testimonial: service.NewTestimonial(cfg.Concurrency.ImageBuffer)}
+	images := testimonial.ScrapeCompanyImages(ctx, session.scraper, company)
+
+	result := companyTestimonials{Company: company.CompanyName, URL: company.CompanyURL, ID: company.SeedCompanyId, Images: len(images), Names: []string{}}
+	if common.dryRun {
+		names, unresolved := service.SplitBySignals(images, company.CompanyURL)
+		result.Names = append(result.Names, names...)
+		for _, image := range unresolved {
+			result.Unresolved = append(result.Unresolved, image.URL)
+		}
+	} else {
+		result.Names = append(result.Names, session.vision.ExtractTextFromImage(ctx, images, company.CompanyURL, session.scraper, -1, company.SeedCompanyId)...)
+		result.Saved = len(result.Names) > 0
+	}
+
+	emit(common, result, func(w io.Writer) {
+		fmt.Fprintf(w, "%s (%s): %d candidate logo(s)\n", result.Company, result.URL, result.Images)
+		for _, name := range result.Names {
+			fmt.Fprintf(w, "  %s\n", name)
+		}
+		if len(result.Unresolved) > 0 {
+			fmt.Fprintf(w, "\n%d logo(s) need OCR:\n", len(result.Unresolved))
+			for _, src := range result.Unresolved {
+				fmt.Fprintf(w, "  %s\n", src)
+			}
+		}
+	})
+	return 0
+}
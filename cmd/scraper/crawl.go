@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/chandhuDev/JobLoop/internal/config"
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	service "github.com/chandhuDev/JobLoop/internal/service"
+)
+
+type crawlPlan struct {
+	Config       string      `json:"config"`
+	Profile      string      `json:"profile"`
+	Seeds        []crawlSeed `json:"seeds"`
+	MaxCompanies int         `json:"max_companies"`
+	Workers      struct {
+		Search      int `json:"search"`
+		Testimonial int `json:"testimonial"`
+		Image       int `json:"image"`
+	} `json:"workers"`
+	CompanyTimeout string `json:"company_timeout"`
+}
+
+type crawlSeed struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	URL     string `json:"url"`
+	Enabled bool   `json:"enabled"`
+}
+
+// runCrawl runs the whole pipeline. With --dry-run it prints the seeds and
+// limits the crawl would run with and exits.
+func runCrawl(args []string) int {
+	var common commonFlags
+	fs := newFlagSet("crawl", "", &common)
+	if _, ok := parseCommand(fs, &common, args, 0); !ok {
+		return 2
+	}
+
+	cfg, ok := loadConfig(common)
+	if !ok {
+		return 1
+	}
+
+	if common.dryRun {
+		plan := newCrawlPlan(cfg)
+		emit(common, plan, func(w io.Writer) {
+			fmt.Fprintf(w, "config:  %s\nprofile: %s\n\nseeds:\n", plan.Config, plan.Profile)
+			for _, seed := range plan.Seeds {
+				state := "enabled"
+				if !seed.Enabled {
+					state = "disabled"
+				}
+				fmt.Fprintf(w, "  %-16s %-12s %-9s %s\n", seed.Name, seed.Kind, state, seed.URL)
+			}
+			fmt.Fprintf(w, "\nmax companies:   %d\nworkers:         %d search, %d testimonial, %d image\ncompany timeout: %s\n",
+				plan.MaxCompanies, plan.Workers.Search, plan.Workers.Testimonial, plan.Workers.Image, plan.CompanyTimeout)
+		})
+		return 0
+	}
+
+	if !requireEnv("ANTHROPIC_API_KEY") {
+		return 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// intake ends first so in-flight companies can finish during the grace period
+	intake, stopIntake := context.WithCancel(ctx)
+
+	// Handle signals
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		sig := <-sigChan
+		grace := service.ShutdownGracePeriod()
+		logger.Info().Str("signal", sig.String()).Dur("grace_period", grace).Msg("Signal received, draining in-flight work...")
+		stopIntake()
+
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+		select {
+		case sig = <-sigChan:
+			logger.Warn().Str("signal", sig.String()).Msg("Second signal received, stopping in-flight work now")
+		case <-timer.C:
+			logger.Warn().Msg("Grace period over, stopping in-flight work")
+		}
+		cancel()
+	}()
+
+	return crawl(ctx, intake, cfg)
+}
+
+func newCrawlPlan(cfg *config.Config) crawlPlan {
+	plan := crawlPlan{
+		Config:         cfg.Path,
+		Profile:        cfg.Profile,
+		MaxCompanies:   cfg.Limits.MaxCompanies,
+		CompanyTimeout: cfg.Timeouts.Company.String(),
+	}
+	if plan.Config == "" {
+		plan.Config = "built-in defaults"
+	}
+	if plan.Profile == "" {
+		plan.Profile = "base"
+	}
+	plan.Workers.Search = cfg.Concurrency.SearchWorkers
+	plan.Workers.Testimonial = cfg.Concurrency.TestimonialWorkers
+	plan.Workers.Image = cfg.Concurrency.ImageWorkers
+	for _, seed := range cfg.Seeds {
+		plan.Seeds = append(plan.Seeds, crawlSeed{Name: seed.Name, Kind: seed.Kind, URL: seed.URL, Enabled: seed.IsEnabled()})
+	}
+	return plan
+}
+
+func crawl(ctx context.Context, intake context.Context, cfg *config.Config) int {
+
+	// Database
+	dbSvc, ok := openDatabase(true)
+	if !ok {
+		return 1
+	}
+	defer dbSvc.Close()
+
+	// Browser
+	browserInstance, err := service.CreateNewBrowser(browserOptions(), ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("error creating browser")
+		return 1
+	}
+	defer func() {
+		logger.Info().Msg("Closing browser...")
+		browserInstance.Close()
+		logger.Info().Msg("Browser closed")
+	}()
+
+	runID := service.NewRunID()
+	logger.Info().Str("run_id", runID).Msg("Starting scraper run")
+
+	usage := service.NewUsageTracker(dbSvc.GetDB(), runID)
+
+	searchInstance := service.CreateSearchService()
+
+	searchEngine, err := service.LoadFakeSearchEngine(os.Getenv("RESOLVER_FAKE_SEARCH_FILE"))
+	if err != nil {
+		logger.Error().Err(err).Msg("error loading search fixtures")
+		return 1
+	}
+
+	searchConfig := service.SetUpSearch(searchInstance)
+	search := &service.SearchService{
+		Client:    searchConfig,
+		Resolvers: service.NewResolverChain(searchInstance, searchEngine, usage),
+		Verifier:  service.NewSiteVerifier(),
+		DB:        dbSvc.GetDB(),
+	}
+
+	namesChannel := service.CreateNamesChannel(cfg.Concurrency.NamesBuffer)
+	defer namesChannel.CloseNamesChan()
+
+	visionInstance := service.CreateVisionInstance()
+
+	visionProvider, err := service.NewVisionProvider(visionInstance, dbSvc.GetDB(), namesChannel.ReturnNamesChan(), usage)
+	if err != nil {
+		logger.Error().Err(err).Msg("error creating vision provider")
+		return 1
+	}
+
+	visionConfig := service.SetUpVision(visionInstance, ctx, namesChannel.ReturnNamesChan())
+	visionWrapper := &service.VisionWrapper{Vision: visionConfig, Provider: visionProvider}
+
+	artifactConfig := service.NewArtifactRecorder(runID)
+	artifacts := &service.ArtifactService{Artifact: artifactConfig}
+
+	// Scraper client
+	scraperClient := service.SetUpScraperClient(
+		browserInstance,
+		visionInstance,
+		search,
+		dbSvc,
+		namesChannel.ReturnNamesChan(),
+		artifacts,
+		runID,
+		usage,
+	)
+
+	if scraperClient == nil || scraperClient.Search == nil || scraperClient.Browser == nil {
+		logger.Error().Msg("scraper client not properly initialized")
+		return 1
+	}
+	scraperClient.Draining = intake.Done()
+
+	for lang, k := range cfg.Keywords {
+		service.ExtendLocaleCatalogue(lang, service.LocaleKeywords{
+			Careers:   k.Careers,
+			CTAs:      k.CTAs,
+			Paths:     k.Paths,
+			Content:   k.Content,
+			JobTitles: k.JobTitles,
+		})
+	}
+
+	seedCompanyArrayInstance := service.NewSeedCompanyArray(cfg.Concurrency.SeedBuffer, cfg.SeedCompanies()...)
+	seedCompany := service.SeedCompanyService{SeedCompany: seedCompanyArrayInstance}
+
+	testimonialConfig := service.NewTestimonial(cfg.Concurrency.ImageBuffer)
+	testimonial := service.TestimonialService{Testimonial: testimonialConfig}
+
+	// Channels to track scraper completion
+	done := make(chan struct{})
+	seedDone := make(chan struct{})
+
+	// Run scrapers
+	go func() {
+		defer close(seedDone)
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error().Interface("error", r).Msg("Panic in SeedCompany")
+			}
+		}()
+		seedCompany.SeedCompanyConfigs(ctx, scraperClient)
+		logger.Info().Msg("SeedCompany scraping completed")
+	}()
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error().Interface("error", r).Msg("Panic in Testimonial")
+			}
+		}()
+		testimonial.ScrapeTestimonial(ctx, scraperClient,
+			seedCompany.SeedCompany.ResultChan,
+			*visionWrapper)
+		logger.Info().Msg("Testimonial scraping completed")
+		close(done)
+	}()
+
+	// Shared OCR batches are submitted and collected in the background
+	collectorCtx, stopCollector := context.WithCancel(ctx)
+	collectorDone := make(chan struct{})
+	batcher, batching := visionProvider.(*service.OCRBatcher)
+	if batching {
+		go func() {
+			defer close(collectorDone)
+			batcher.Run(collectorCtx)
+		}()
+	} else {
+		close(collectorDone)
+	}
+
+	// Wait for either completion or cancellation. While draining the stages
+	// finish in-flight companies and checkpoint the rest, then done closes.
+	select {
+	case <-done:
+		if intake.Err() != nil {
+			logger.Info().Msg("Drain complete, unfinished work checkpointed")
+		} else {
+			logger.Info().Msg("All scraping tasks completed successfully")
+		}
+		if batching {
+			batcher.Flush(ctx)
+			logger.Info().Msg("Queued OCR images submitted; open batches are collected by the next run")
+		}
+	case <-ctx.Done():
+		logger.Info().Msg("Scraping interrupted, checkpointing in-flight work")
+		waitForCheckpoints(done, seedDone)
+	}
+
+	// Stop the collector before the names channel is closed
+	stopCollector()
+	<-collectorDone
+
+	if intake.Err() != nil {
+		service.CheckpointNames(scraperClient)
+	}
+
+	logger.Info().Msg("Shutdown complete")
+	return 0
+}
+
+// waitForCheckpoints gives cancelled stages a moment to write what they did
+// not finish before the database connection closes.
+func waitForCheckpoints(stages ...<-chan struct{}) {
+	timeout := time.After(30 * time.Second)
+	for _, stage := range stages {
+		select {
+		case <-stage:
+		case <-timeout:
+			logger.Warn().Msg("Timed out waiting for stages to checkpoint")
+			return
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	models "github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/repository"
+)
+
+// Scrape failures of the job scraper are recorded under this stage
+const failureStageJobs = "jobs"
+
+// runReclassify re-runs the noise and engineering filters over stored jobs.
+func runReclassify(args []string) int {
+	var common commonFlags
+	fs := newFlagSet("reclassify", "", &common)
+	if _, ok := parseCommand(fs, &common, args, 0); !ok {
+		return 2
+	}
+
+	if _, ok := loadConfig(common); !ok {
+		return 1
+	}
+
+	dbSvc, ok := openDatabase(true)
+	if !ok {
+		return 1
+	}
+	defer dbSvc.Close()
+
+	result, err := repository.ReclassifyJobs(dbSvc.GetDB(), common.dryRun)
+	if err != nil {
+		logger.Error().Err(err).Msg("reclassification failed")
+		return 1
+	}
+
+	emit(common, result, func(w io.Writer) {
+		fmt.Fprintf(w, "scanned:          %d\nupdated:          %d\nto engineering:   %d\nfrom engineering: %d\nmoved to noise:   %d\n",
+			result.Scanned, result.Updated, result.ToEngineering, result.FromEngineering, result.MovedToNoise)
+		if result.DryRun {
+			fmt.Fprintln(w, "(dry run, nothing changed)")
+		}
+	})
+	return 0
+}
+
+// runRetryFailed scrapes jobs again for companies whose job scrape failed
+// and that still have no jobs. With --dry-run it only lists them.
+func runRetryFailed(args []string) int {
+	var common commonFlags
+	fs := newFlagSet("retry-failed", "", &common)
+	since := fs.Duration("since", 7*24*time.Hour, "retry failures recorded within this window")
+	limit := fs.Int("limit", 50, "retry at most this many companies")
+	runID := fs.String("run-id", "", "retry only failures from this run")
+	if _, ok := parseCommand(fs, &common, args, 0); !ok {
+		return 2
+	}
+
+	cfg, ok := loadConfig(common)
+	if !ok {
+		return 1
+	}
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	if common.dryRun {
+		dbSvc, ok := openDatabase(false)
+		if !ok {
+			return 1
+		}
+		defer dbSvc.Close()
+
+		companies, err := repository.ListFailedCompanies(dbSvc.GetDB().WithContext(ctx), failureStageJobs, time.Now().Add(-*since), *runID, *limit)
+		if err != nil {
+			logger.Error().Err(err).Msg("error listing failed companies")
+			return 1
+		}
+
+		results := make([]companyJobs, 0, len(companies))
+		for _, company := range companies {
+			results = append(results, companyJobs{Company: company.CompanyName, URL: company.CompanyURL, ID: company.ID, Jobs: []jobRow{}})
+		}
+		emit(common, results, func(w io.Writer) {
+			for _, result := range results {
+				fmt.Fprintf(w, "%6d  %-30s %s\n", result.ID, result.Company, result.URL)
+			}
+			fmt.Fprintf(w, "%d companies would be retried\n", len(results))
+		})
+		return 0
+	}
+
+	session, ok := openCompanySession(ctx, common, cfg)
+	if !ok {
+		return 1
+	}
+	defer session.Close()
+
+	companies, err := repository.ListFailedCompanies(session.db.GetDB().WithContext(ctx), failureStageJobs, time.Now().Add(-*since), *runID, *limit)
+	if err != nil {
+		logger.Error().Err(err).Msg("error listing failed companies")
+		return 1
+	}
+
+	results := make([]companyJobs, 0, len(companies))
+	for _, company := range companies {
+		if ctx.Err() != nil {
+			break
+		}
+		results = append(results, scrapeCompanyJobs(ctx, session, models.SeedCompanyResult{
+			CompanyName:   company.CompanyName,
+			CompanyURL:    company.CompanyURL,
+			SeedCompanyId: company.ID,
+		}))
+	}
+
+	emit(common, results, func(w io.Writer) {
+		recovered := 0
+		for _, result := range results {
+			status := fmt.Sprintf("%d job(s)", len(result.Jobs))
+			if result.Error != "" {
+				status = "failed: " + result.Error
+			} else if len(result.Jobs) > 0 {
+				recovered++
+			}
+			fmt.Fprintf(w, "%6d  %-30s %s\n", result.ID, result.Company, status)
+		}
+		fmt.Fprintf(w, "%d of %d companies recovered\n", recovered, len(results))
+	})
+	return 0
+}
+
+// runStats prints table counts, checkpointed work and today's LLM spend.
+func runStats(args []string) int {
+	var common commonFlags
+	fs := newFlagSet("stats", "", &common)
+	if _, ok := parseCommand(fs, &common, args, 0); !ok {
+		return 2
+	}
+
+	if _, ok := loadConfig(common); !ok {
+		return 1
+	}
+
+	dbSvc, ok := openDatabase(false)
+	if !ok {
+		return 1
+	}
+	defer dbSvc.Close()
+
+	stats, err := repository.CollectScraperStats(dbSvc.GetDB())
+	if err != nil {
+		logger.Error().Err(err).Msg("error collecting stats")
+		return 1
+	}
+
+	emit(common, stats, func(w io.Writer) {
+		fmt.Fprintf(w, "companies:          %d (%d with jobs)\n", stats.Companies, stats.CompaniesWithJobs)
+		fmt.Fprintf(w, "jobs:               %d engineering, %d other\n", stats.EngineeringJobs, stats.OtherJobs)
+		fmt.Fprintf(w, "noise:              %d\n", stats.Noise)
+		fmt.Fprintf(w, "testimonials:       %d\n", stats.Testimonials)
+		fmt.Fprintf(w, "resolutions:        %d\n", stats.Resolutions)
+		fmt.Fprintf(w, "failures (24h):     %d\n", stats.FailuresLastDay)
+		fmt.Fprintf(w, "queued OCR images:  %d in %d open batch(es)\n", stats.QueuedOCRImages, stats.OpenOCRBatches)
+		fmt.Fprintf(w, "LLM spend today:    $%.2f\n", stats.SpendTodayUSD)
+
+		stages := make([]string, 0, len(stats.PendingWork))
+		for stage := range stats.PendingWork {
+			stages = append(stages, stage)
+		}
+		sort.Strings(stages)
+		for _, stage := range stages {
+			fmt.Fprintf(w, "pending %-11s %d\n", stage+":", stats.PendingWork[stage])
+		}
+	})
+	return 0
+}
+
+// runMigrate creates or updates the schema. With --dry-run it lists the
+// tables that are missing.
+func runMigrate(args []string) int {
+	var common commonFlags
+	fs := newFlagSet("migrate", "", &common)
+	if _, ok := parseCommand(fs, &common, args, 0); !ok {
+		return 2
+	}
+
+	if _, ok := loadConfig(common); !ok {
+		return 1
+	}
+
+	dbSvc, ok := openDatabase(false)
+	if !ok {
+		return 1
+	}
+	defer dbSvc.Close()
+
+	missing, err := dbSvc.MissingTables()
+	if err != nil {
+		logger.Error().Err(err).Msg("error inspecting schema")
+		return 1
+	}
+
+	if !common.dryRun {
+		if err := dbSvc.CreateSchema(); err != nil {
+			logger.Error().Err(err).Msg("error creating schema")
+			return 1
+		}
+	}
+
+	result := struct {
+		Created []string `json:"created"`
+		DryRun  bool     `json:"dry_run"`
+	}{Created: missing, DryRun: common.dryRun}
+	if result.Created == nil {
+		result.Created = []string{}
+	}
+
+	emit(common, result, func(w io.Writer) {
+		verb := "created"
+		if result.DryRun {
+			verb = "would create"
+		}
+		if len(result.Created) == 0 {
+			if result.DryRun {
+				fmt.Fprintln(w, "no missing tables")
+			} else {
+				fmt.Fprintln(w, "schema up to date")
+			}
+			return
+		}
+		for _, table := range result.Created {
+			fmt.Fprintf(w, "%s %s\n", verb, table)
+		}
+	})
+	return 0
+}
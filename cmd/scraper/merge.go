@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	"github.com/chandhuDev/JobLoop/internal/repository"
+	service "github.com/chandhuDev/JobLoop/internal/service"
+)
+
+type canonicalizeResult struct {
+	Updated int  `json:"updated"`
+	Merged  int  `json:"merged"`
+	DryRun  bool `json:"dry_run"`
+}
+
+// runMerge merges one duplicate company into a survivor, or with
+// --canonicalize backfills canonical domains and merges every company that
+// shares one.
+func runMerge(args []string) int {
+	var common commonFlags
+	fs := newFlagSet("merge", "", &common)
+	survivor := fs.Uint("survivor", 0, "ID of the company to keep")
+	duplicate := fs.Uint("duplicate", 0, "ID of the company to merge into the survivor")
+	canonicalize := fs.Bool("canonicalize", false, "backfill canonical domains and merge companies sharing one")
+	if _, ok := parseCommand(fs, &common, args, 0); !ok {
+		return 2
+	}
+
+	if !*canonicalize && (*survivor == 0 || *duplicate == 0) {
+		logger.Error().Msg("merge needs --survivor and --duplicate, or --canonicalize")
+		fs.Usage()
+		return 2
+	}
+	if !*canonicalize && common.dryRun {
+		logger.Error().Msg("--dry-run only applies to --canonicalize")
+		return 2
+	}
+
+	if _, ok := loadConfig(common); !ok {
+		return 1
+	}
+
+	dbSvc, ok := openDatabase(true)
+	if !ok {
+		return 1
+	}
+	defer dbSvc.Close()
+
+	if *canonicalize {
+		ctx, stop := signalContext()
+		defer stop()
+
+		updated, merged, err := service.CanonicalizeSeedCompanies(ctx, dbSvc.GetDB(), common.dryRun)
+		if err != nil {
+			logger.Error().Err(err).Msg("canonicalization failed")
+			return 1
+		}
+		result := canonicalizeResult{Updated: updated, Merged: merged, DryRun: common.dryRun}
+		emit(common, result, func(w io.Writer) {
+			fmt.Fprintf(w, "updated: %d\nmerged:  %d\n", result.Updated, result.Merged)
+		})
+		return 0
+	}
+
+	result, err := repository.MergeSeedCompanies(dbSvc.GetDB(), *survivor, *duplicate)
+	if err != nil {
+		logger.Error().Err(err).Msg("merge failed")
+		return 1
+	}
+
+	emit(common, result, func(w io.Writer) {
+		fmt.Fprintf(w, "merged company %d into %d\n", result.DuplicateID, result.SurvivorID)
+		fmt.Fprintf(w, "jobs moved:   %d (%d dropped as duplicates)\ntestimonials: %d\nnoise:        %d\nfailures:     %d\n",
+			result.JobsMoved, result.JobsDropped, result.Testimonials, result.Noise, result.Failures)
+		fmt.Fprintf(w, "OCR images:   %d\ncheckpoints:  %d\nLLM usage:    %d\n", result.OCRItems, result.PendingWork, result.LLMUsage)
+	})
+	return 0
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	service "github.com/chandhuDev/JobLoop/internal/service"
+	"gorm.io/gorm"
+)
+
+type resolveResult struct {
+	Company string `json:"company"`
+	URL     string `json:"url,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// runResolve looks up one company's website through the resolver chain. With
+// --dry-run the resolution cache is neither read nor written.
+func runResolve(args []string) int {
+	var common commonFlags
+	fs := newFlagSet("resolve", "<name>", &common)
+	rest, ok := parseCommand(fs, &common, args, 1)
+	if !ok {
+		return 2
+	}
+
+	if _, ok := loadConfig(common); !ok {
+		return 1
+	}
+	if !requireEnv("ANTHROPIC_API_KEY") {
+		return 1
+	}
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	var db *gorm.DB
+	if !common.dryRun {
+		dbSvc, ok := openDatabase(true)
+		if !ok {
+			return 1
+		}
+		defer dbSvc.Close()
+		db = dbSvc.GetDB()
+	}
+
+	searchEngine, err := service.LoadFakeSearchEngine(os.Getenv("RESOLVER_FAKE_SEARCH_FILE"))
+	if err != nil {
+		logger.Error().Err(err).Msg("error loading search fixtures")
+		return 1
+	}
+
+	searchInstance := service.CreateSearchService()
+	usage := service.NewUsageTracker(db, service.NewRunID())
+	search := &service.SearchService{
+		Client:    service.SetUpSearch(searchInstance),
+		Resolvers: service.NewResolverChain(searchInstance, searchEngine, usage),
+		Verifier:  service.NewSiteVerifier(),
+		DB:        db,
+	}
+
+	result := resolveResult{Company: rest[0]}
+	url, err := search.SearchKeyword(ctx, rest[0], -1)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	result.URL = url
+
+	emit(common, result, func(w io.Writer) {
+		if result.Error != "" {
+			fmt.Fprintf(w, "%s: %s\n", result.Company, result.Error)
+			return
+		}
+		fmt.Fprintf(w, "%s: %s\n", result.Company, result.URL)
+	})
+	if result.Error != "" {
+		return 1
+	}
+	return 0
+}
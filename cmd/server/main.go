@@ -34,7 +34,7 @@ func main() {
 
 	logger.Info().Msg("Database connected successfully")
 
-	h := handlers.NewHandlers(db)
+	h := handlers.NewHandlers(db, os.Getenv("ADMIN_TOKEN"))
 	mux := http.NewServeMux()
 	h.RegisterRoutes(mux)
 
@@ -0,0 +1,481 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chandhuDev/JobLoop/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath is read when SCRAPER_CONFIG is not set. A missing default file
+// is not an error; the built-in defaults are used instead.
+const DefaultPath = "configs/scraper.yaml"
+
+const (
+	SeedKindYCombinator = "ycombinator"
+	SeedKindPeerList    = "peerlist"
+)
+
+/* ================= TYPES ================= */
+
+// Config is the scraper configuration. Values are resolved in order: built-in
+// defaults, the config file, the selected profile, then environment variables
+// named by the env tags. Export writes the resolved values back to those
+// variables, which is where the services read them.
+type Config struct {
+	Path    string `yaml:"-"`
+	Profile string `yaml:"-"`
+
+	Seeds       []Seed              `yaml:"seeds"`
+	Concurrency Concurrency         `yaml:"concurrency"`
+	Timeouts    Timeouts            `yaml:"timeouts"`
+	Keywords    map[string]Keywords `yaml:"keywords"`
+	Models      Models              `yaml:"models"`
+	Limits      Limits              `yaml:"limits"`
+}
+
+type Seed struct {
+	Name     string        `yaml:"name"`
+	Kind     string        `yaml:"kind"`
+	URL      string        `yaml:"url"`
+	Selector string        `yaml:"selector"`
+	WaitTime time.Duration `yaml:"wait_time"`
+	Enabled  *bool         `yaml:"enabled"`
+}
+
+type Concurrency struct {
+	SearchWorkers       int `yaml:"search_workers" env:"SEARCH_WORKERS"`
+	TestimonialWorkers  int `yaml:"testimonial_workers" env:"TESTIMONIAL_WORKERS"`
+	ImageWorkers        int `yaml:"image_workers" env:"IMAGE_WORKERS"`
+	RealtimeVisionCalls int `yaml:"realtime_vision_calls" env:"VISION_REALTIME_CONCURRENCY"`
+	NamesBuffer         int `yaml:"names_buffer" env:"NAMES_BUFFER"`
+	SeedBuffer          int `yaml:"seed_buffer" env:"SEED_BUFFER"`
+	ImageBuffer         int `yaml:"image_buffer" env:"IMAGE_BUFFER"`
+}
+
+type Timeouts struct {
+	Company               time.Duration `yaml:"company" env:"COMPANY_TIME_BUDGET"`
+	ShutdownGrace         time.Duration `yaml:"shutdown_grace" env:"SHUTDOWN_GRACE_PERIOD"`
+	SeedDelay             time.Duration `yaml:"seed_delay" env:"SEED_DELAY"`
+	SearchDelay           time.Duration `yaml:"search_delay" env:"SEARCH_DELAY"`
+	OCRBatchWindow        time.Duration `yaml:"ocr_batch_window" env:"OCR_BATCH_WINDOW"`
+	OCRBatchPollInterval  time.Duration `yaml:"ocr_batch_poll_interval" env:"OCR_BATCH_POLL_INTERVAL"`
+	ResolutionTTL         time.Duration `yaml:"resolution_ttl" env:"RESOLUTION_TTL"`
+	ResolutionNegativeTTL time.Duration `yaml:"resolution_negative_ttl" env:"RESOLUTION_NEGATIVE_TTL"`
+}
+
+// Keywords extend the built-in careers vocabulary of one language.
+type Keywords struct {
+	Careers   []string `yaml:"careers"`
+	CTAs      []string `yaml:"ctas"`
+	Paths     []string `yaml:"paths"`
+	Content   []string `yaml:"content"`
+	JobTitles []string `yaml:"job_titles"`
+}
+
+type Models struct {
+	Vision        string `yaml:"vision" env:"VISION_MODEL"`
+	Resolver      string `yaml:"resolver" env:"RESOLVER_MODEL"`
+	JobExtraction string `yaml:"job_extraction" env:"JOB_EXTRACTION_MODEL"`
+}
+
+type Limits struct {
+	MaxCompanies           int     `yaml:"max_companies" env:"MAX_LEN"`
+	LLMJobExtractionBudget int     `yaml:"llm_job_extraction_budget" env:"LLM_JOB_EXTRACTION_BUDGET"`
+	OCRBatchSize           int     `yaml:"ocr_batch_size" env:"OCR_BATCH_SIZE"`
+	RunBudgetUSD           float64 `yaml:"run_budget_usd" env:"LLM_RUN_BUDGET_USD"`
+	DailyBudgetUSD         float64 `yaml:"daily_budget_usd" env:"LLM_DAILY_BUDGET_USD"`
+}
+
+// file is the on-disk layout: a base config plus named partial overrides.
+type file struct {
+	Config   `yaml:",inline"`
+	Profiles map[string]yaml.Node `yaml:"profiles"`
+}
+
+/* ================= DEFAULTS ================= */
+
+// Default is the configuration the scraper ran with before it had a config
+// file.
+func Default() *Config {
+	return &Config{
+		Seeds: []Seed{
+			{
+				Name:     "Y Combinator",
+				Kind:     SeedKindYCombinator,
+				URL:      "http://www.ycombinator.com/companies",
+				Selector: `a[href^="/companies/"]`,
+				WaitTime: 10 * time.Second,
+			},
+			{
+				Name:     "Peer list",
+				Kind:     SeedKindPeerList,
+				URL:      "https://peerlist.io/jobs",
+				Selector: `a[href^="/company/"][href*="/careers/"]`,
+				WaitTime: 3 * time.Second,
+				Enabled:  boolPtr(false),
+			},
+		},
+		Concurrency: Concurrency{
+			SearchWorkers:       2,
+			TestimonialWorkers:  4,
+			ImageWorkers:        4,
+			RealtimeVisionCalls: 4,
+			NamesBuffer:         200,
+			SeedBuffer:          500,
+			ImageBuffer:         250,
+		},
+		Timeouts: Timeouts{
+			Company:               5 * time.Minute,
+			ShutdownGrace:         60 * time.Second,
+			SeedDelay:             3 * time.Second,
+			SearchDelay:           5 * time.Second,
+			OCRBatchWindow:        2 * time.Minute,
+			OCRBatchPollInterval:  30 * time.Second,
+			ResolutionTTL:         90 * 24 * time.Hour,
+			ResolutionNegativeTTL: 7 * 24 * time.Hour,
+		},
+		Models: Models{
+			Vision:        "claude-sonnet-4-5-20250929",
+			Resolver:      "claude-sonnet-4-5-20250929",
+			JobExtraction: "claude-sonnet-4-5-20250929",
+		},
+		Limits: Limits{
+			MaxCompanies:           200,
+			LLMJobExtractionBudget: 25,
+			OCRBatchSize:           100,
+		},
+	}
+}
+
+/* ================= LOAD ================= */
+
+// Load reads the config file at path (DefaultPath when empty), applies the
+// named profile and the environment overrides, and validates the result.
+func Load(path string, profile string) (*Config, error) {
+	cfg := Default()
+
+	explicit := path != ""
+	if !explicit {
+		path = DefaultPath
+	}
+	cfg.Path = path
+	cfg.Profile = profile
+
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := cfg.decodeFile(data, profile); err != nil {
+			return nil, fmt.Errorf("config %s: %w", path, err)
+		}
+	case errors.Is(err, os.ErrNotExist) && !explicit:
+		cfg.Path = ""
+		if profile != "" {
+			return nil, fmt.Errorf("profile %q requested but no config file found at %s", profile, path)
+		}
+	default:
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	if err := applyEnv(reflect.ValueOf(cfg).Elem()); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) decodeFile(data []byte, profile string) error {
+	f := file{Config: *c}
+	if err := decodeStrict(data, &f); err != nil {
+		return err
+	}
+	*c = f.Config
+
+	if profile == "" {
+		return nil
+	}
+
+	node, ok := f.Profiles[profile]
+	if !ok {
+		names := make([]string, 0, len(f.Profiles))
+		for name := range f.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown profile %q (available: %s)", profile, strings.Join(names, ", "))
+	}
+
+	// Profiles are decoded over the base: scalars and lists they set replace
+	// the base values, keyword languages are merged
+	overlay, err := yaml.Marshal(&node)
+	if err != nil {
+		return err
+	}
+	if err := decodeStrict(overlay, c); err != nil {
+		return fmt.Errorf("profile %q: %w", profile, err)
+	}
+	return nil
+}
+
+// decodeStrict rejects keys the config does not know so typos fail loudly.
+func decodeStrict(data []byte, out interface{}) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+/* ================= ENV ================= */
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnv overrides every field with an env tag whose variable is set.
+func applyEnv(v reflect.Value) error {
+	var errs []string
+	walkEnv(v, func(name string, field reflect.Value) {
+		raw := strings.TrimSpace(os.Getenv(name))
+		if raw == "" {
+			return
+		}
+		if err := setField(field, raw); err != nil {
+			errs = append(errs, fmt.Sprintf("%s=%q: %v", name, raw, err))
+		}
+	})
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid environment overrides:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+	return nil
+}
+
+// Export sets every env-tagged variable to its resolved value so code that
+// reads the environment sees the file and profile values too.
+func (c *Config) Export() {
+	walkEnv(reflect.ValueOf(c).Elem(), func(name string, field reflect.Value) {
+		os.Setenv(name, formatField(field))
+	})
+}
+
+func walkEnv(v reflect.Value, fn func(name string, field reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if name := t.Field(i).Tag.Get("env"); name != "" {
+			fn(name, field)
+			continue
+		}
+		if field.Kind() == reflect.Struct && field.Type() != durationType {
+			walkEnv(field, fn)
+		}
+	}
+}
+
+func setField(field reflect.Value, raw string) error {
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("not a duration")
+		}
+		field.SetInt(int64(d))
+	case field.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("not an integer")
+		}
+		field.SetInt(int64(n))
+	case field.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("not a number")
+		}
+		field.SetFloat(f)
+	case field.Kind() == reflect.String:
+		field.SetString(raw)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+func formatField(field reflect.Value) string {
+	switch {
+	case field.Type() == durationType:
+		return time.Duration(field.Int()).String()
+	case field.Kind() == reflect.Int:
+		return strconv.FormatInt(field.Int(), 10)
+	case field.Kind() == reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 64)
+	default:
+		return field.String()
+	}
+}
+
+/* ================= VALIDATE ================= */
+
+var languageRegex = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// Validate reports every problem at once, each prefixed with the setting it
+// concerns.
+func (c *Config) Validate() error {
+	var errs []string
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+
+	enabled := 0
+	seen := make(map[string]bool)
+	for i, s := range c.Seeds {
+		prefix := fmt.Sprintf("seeds[%d]", i)
+		if s.Name == "" {
+			add("%s.name: required", prefix)
+		} else {
+			prefix = fmt.Sprintf("seeds[%d] (%s)", i, s.Name)
+			if seen[s.Name] {
+				add("%s.name: duplicate seed name", prefix)
+			}
+			seen[s.Name] = true
+		}
+		if s.Kind != SeedKindYCombinator && s.Kind != SeedKindPeerList {
+			add("%s.kind: must be %q or %q, got %q", prefix, SeedKindYCombinator, SeedKindPeerList, s.Kind)
+		}
+		if u, err := url.Parse(s.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("%s.url: must be an absolute http(s) URL, got %q", prefix, s.URL)
+		}
+		if strings.TrimSpace(s.Selector) == "" {
+			add("%s.selector: required", prefix)
+		}
+		if s.WaitTime < 0 {
+			add("%s.wait_time: must not be negative", prefix)
+		}
+		if s.IsEnabled() {
+			enabled++
+		}
+	}
+	if enabled == 0 {
+		add("seeds: at least one seed source must be enabled")
+	}
+
+	for name, n := range map[string]int{
+		"concurrency.search_workers":        c.Concurrency.SearchWorkers,
+		"concurrency.testimonial_workers":   c.Concurrency.TestimonialWorkers,
+		"concurrency.image_workers":         c.Concurrency.ImageWorkers,
+		"concurrency.realtime_vision_calls": c.Concurrency.RealtimeVisionCalls,
+		"concurrency.names_buffer":          c.Concurrency.NamesBuffer,
+		"concurrency.seed_buffer":           c.Concurrency.SeedBuffer,
+		"concurrency.image_buffer":          c.Concurrency.ImageBuffer,
+		"limits.max_companies":              c.Limits.MaxCompanies,
+		"limits.ocr_batch_size":             c.Limits.OCRBatchSize,
+	} {
+		if n < 1 {
+			add("%s: must be at least 1, got %d", name, n)
+		}
+	}
+
+	for name, d := range map[string]time.Duration{
+		"timeouts.company":                 c.Timeouts.Company,
+		"timeouts.shutdown_grace":          c.Timeouts.ShutdownGrace,
+		"timeouts.seed_delay":              c.Timeouts.SeedDelay,
+		"timeouts.search_delay":            c.Timeouts.SearchDelay,
+		"timeouts.ocr_batch_window":        c.Timeouts.OCRBatchWindow,
+		"timeouts.ocr_batch_poll_interval": c.Timeouts.OCRBatchPollInterval,
+		"timeouts.resolution_ttl":          c.Timeouts.ResolutionTTL,
+		"timeouts.resolution_negative_ttl": c.Timeouts.ResolutionNegativeTTL,
+	} {
+		if d <= 0 {
+			add("%s: must be a positive duration such as \"30s\" or \"5m\"", name)
+		}
+	}
+	for lang, k := range c.Keywords {
+		if !languageRegex.MatchString(lang) {
+			add("keywords.%s: language must be a lowercase ISO 639 code such as \"en\" or \"de\"", lang)
+		}
+		if len(k.Careers)+len(k.CTAs)+len(k.Paths)+len(k.Content)+len(k.JobTitles) == 0 {
+			add("keywords.%s: no keywords listed", lang)
+		}
+		for _, p := range k.Paths {
+			if !strings.HasPrefix(p, "/") {
+				add("keywords.%s.paths: %q must start with \"/\"", lang, p)
+			}
+		}
+	}
+
+	for name, model := range map[string]string{
+		"models.vision":         c.Models.Vision,
+		"models.resolver":       c.Models.Resolver,
+		"models.job_extraction": c.Models.JobExtraction,
+	} {
+		if strings.TrimSpace(model) == "" {
+			add("%s: required", name)
+		}
+	}
+
+	if c.Limits.LLMJobExtractionBudget < 0 {
+		add("limits.llm_job_extraction_budget: must not be negative (0 disables the fallback)")
+	}
+	if c.Limits.RunBudgetUSD < 0 {
+		add("limits.run_budget_usd: must not be negative (0 means no limit)")
+	}
+	if c.Limits.DailyBudgetUSD < 0 {
+		add("limits.daily_budget_usd: must not be negative (0 means no limit)")
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Strings(errs)
+
+	source := "built-in defaults"
+	if c.Path != "" {
+		source = c.Path
+	}
+	if c.Profile != "" {
+		source += ", profile " + c.Profile
+	}
+	return fmt.Errorf("invalid config (%s):\n  - %s", source, strings.Join(errs, "\n  - "))
+}
+
+/* ================= SEEDS ================= */
+
+// IsEnabled treats a seed without an enabled key as enabled.
+func (s Seed) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// SeedCompanies returns the enabled seed sources.
+func (c *Config) SeedCompanies() []models.SeedCompany {
+	var seeds []models.SeedCompany
+	for _, s := range c.Seeds {
+		if !s.IsEnabled() {
+			continue
+		}
+		seeds = append(seeds, models.SeedCompany{
+			Name:     s.Name,
+			Kind:     s.Kind,
+			URL:      s.URL,
+			Selector: s.Selector,
+			WaitTime: s.WaitTime,
+		})
+	}
+	return seeds
+}
@@ -0,0 +1,253 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv blanks every override so the host environment cannot leak into a
+// test; applyEnv ignores empty variables.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	walkEnv(reflect.ValueOf(Default()).Elem(), func(name string, field reflect.Value) {
+		t.Setenv(name, "")
+	})
+}
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "scraper.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const baseConfig = `
+concurrency:
+  search_workers: 3
+timeouts:
+  company: 2m
+limits:
+  max_companies: 50
+profiles:
+  smoke:
+    limits:
+      max_companies: 5
+    keywords:
+      de:
+        careers: [karriere]
+`
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		profile string
+		env     map[string]string
+		check   func(t *testing.T, cfg *Config)
+		wantErr string
+	}{
+		{
+			name: "file overrides defaults",
+			body: baseConfig,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Concurrency.SearchWorkers != 3 || cfg.Limits.MaxCompanies != 50 || cfg.Timeouts.Company != 2*time.Minute {
+					t.Errorf("file values not applied: %+v %+v %+v", cfg.Concurrency, cfg.Limits, cfg.Timeouts)
+				}
+				if cfg.Concurrency.ImageWorkers != Default().Concurrency.ImageWorkers {
+					t.Errorf("unset value lost its default: image_workers = %d", cfg.Concurrency.ImageWorkers)
+				}
+			},
+		},
+		{
+			name:    "profile applied over the file",
+			body:    baseConfig,
+			profile: "smoke",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Limits.MaxCompanies != 5 {
+					t.Errorf("max_companies = %d, want 5", cfg.Limits.MaxCompanies)
+				}
+				if cfg.Concurrency.SearchWorkers != 3 {
+					t.Errorf("search_workers = %d, want the base value 3", cfg.Concurrency.SearchWorkers)
+				}
+				if got := cfg.Keywords["de"].Careers; len(got) != 1 || got[0] != "karriere" {
+					t.Errorf("keywords.de.careers = %q", got)
+				}
+			},
+		},
+		{
+			name:    "environment overrides the profile",
+			body:    baseConfig,
+			profile: "smoke",
+			env:     map[string]string{"MAX_LEN": "7", "COMPANY_TIME_BUDGET": "90s", "LLM_RUN_BUDGET_USD": "2.5"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Limits.MaxCompanies != 7 || cfg.Timeouts.Company != 90*time.Second || cfg.Limits.RunBudgetUSD != 2.5 {
+					t.Errorf("env values not applied: %+v %+v", cfg.Limits, cfg.Timeouts)
+				}
+			},
+		},
+		{
+			name:    "unknown profile",
+			body:    baseConfig,
+			profile: "nightly",
+			wantErr: `unknown profile "nightly" (available: smoke)`,
+		},
+		{
+			name:    "unknown key",
+			body:    "limits:\n  max_compnies: 5\n",
+			wantErr: "max_compnies",
+		},
+		{
+			name:    "invalid env override",
+			body:    baseConfig,
+			env:     map[string]string{"SEARCH_WORKERS": "two"},
+			wantErr: `SEARCH_WORKERS="two": not an integer`,
+		},
+		{
+			name:    "invalid value",
+			body:    "concurrency:\n  image_workers: 0\n",
+			wantErr: "concurrency.image_workers: must be at least 1, got 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			cfg, err := Load(writeConfig(t, tt.body), tt.profile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	clearEnv(t)
+	t.Chdir(t.TempDir())
+
+	cfg, err := Load("", "")
+	if err != nil {
+		t.Fatalf("Load without a default file: %v", err)
+	}
+	if cfg.Path != "" {
+		t.Errorf("Path = %q, want empty when running on built-in defaults", cfg.Path)
+	}
+
+	if _, err := Load("", "smoke"); err == nil {
+		t.Error("Load with a profile but no file succeeded, want an error")
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml"), ""); err == nil {
+		t.Error("Load of an explicit missing file succeeded, want an error")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   []string
+	}{
+		{
+			name:   "defaults are valid",
+			modify: func(c *Config) {},
+		},
+		{
+			name: "seed problems",
+			modify: func(c *Config) {
+				c.Seeds = append(c.Seeds, Seed{Name: "Y Combinator", Kind: "angellist", URL: "/companies", WaitTime: -time.Second})
+			},
+			want: []string{
+				`seeds[2] (Y Combinator).name: duplicate seed name`,
+				`seeds[2] (Y Combinator).kind: must be "ycombinator" or "peerlist", got "angellist"`,
+				`seeds[2] (Y Combinator).url: must be an absolute http(s) URL, got "/companies"`,
+				`seeds[2] (Y Combinator).selector: required`,
+				`seeds[2] (Y Combinator).wait_time: must not be negative`,
+			},
+		},
+		{
+			name: "no enabled seed",
+			modify: func(c *Config) {
+				c.Seeds = c.Seeds[1:]
+			},
+			want: []string{"seeds: at least one seed source must be enabled"},
+		},
+		{
+			name: "counts and durations",
+			modify: func(c *Config) {
+				c.Concurrency.SearchWorkers = 0
+				c.Timeouts.SeedDelay = 0
+			},
+			want: []string{
+				"concurrency.search_workers: must be at least 1, got 0",
+				`timeouts.seed_delay: must be a positive duration such as "30s" or "5m"`,
+			},
+		},
+		{
+			name: "keywords",
+			modify: func(c *Config) {
+				c.Keywords = map[string]Keywords{
+					"German": {Careers: []string{"karriere"}},
+					"fr":     {Paths: []string{"carrieres"}},
+					"es":     {},
+				}
+			},
+			want: []string{
+				"keywords.German: language must be a lowercase ISO 639 code",
+				`keywords.fr.paths: "carrieres" must start with "/"`,
+				"keywords.es: no keywords listed",
+			},
+		},
+		{
+			name: "models and budgets",
+			modify: func(c *Config) {
+				c.Models.Vision = " "
+				c.Limits.LLMJobExtractionBudget = -1
+				c.Limits.DailyBudgetUSD = -5
+			},
+			want: []string{
+				"models.vision: required",
+				"limits.llm_job_extraction_budget: must not be negative",
+				"limits.daily_budget_usd: must not be negative",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Validate succeeded, want errors")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate error is missing %q:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
@@ -43,10 +43,40 @@ func (db *DatabaseService) CreateSchema() error {
 	if err := db.DB.DB.Exec("CREATE EXTENSION IF NOT EXISTS citext").Error; err != nil {
 		return fmt.Errorf("failed to create citext extension: %w", err)
 	}
-	err := db.DB.DB.AutoMigrate(&schema.SeedCompany{}, &schema.TestimonialCompany{}, &schema.Job{}, &schema.Noise{})
+
+	// Company names are no longer unique (renames, namesakes); the canonical
+	// domain identifies a company instead
+	var uniqueName int64
+	db.DB.DB.Raw("SELECT count(*) FROM pg_indexes WHERE indexname = ? AND indexdef LIKE 'CREATE UNIQUE%'", "idx_seed_companies_company_name").Scan(&uniqueName)
+	if uniqueName > 0 {
+		if err := db.DB.DB.Migrator().DropIndex(&schema.SeedCompany{}, "idx_seed_companies_company_name"); err != nil {
+			return fmt.Errorf("failed to drop unique company name index: %w", err)
+		}
+	}
+
+	err := db.DB.DB.AutoMigrate(schemaModels()...)
 	return err
 }
 
+func schemaModels() []interface{} {
+	return []interface{}{&schema.SeedCompany{}, &schema.TestimonialCompany{}, &schema.Job{}, &schema.Noise{}, &schema.ScrapeFailure{}, &schema.CompanyResolution{}, &schema.ImageCache{}, &schema.OCRBatch{}, &schema.OCRBatchItem{}, &schema.LLMUsage{}, &schema.PendingWork{}}
+}
+
+// MissingTables lists the tables CreateSchema would create.
+func (db *DatabaseService) MissingTables() ([]string, error) {
+	var missing []string
+	for _, model := range schemaModels() {
+		stmt := &gorm.Statement{DB: db.DB.DB}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		if !db.DB.DB.Migrator().HasTable(model) {
+			missing = append(missing, stmt.Schema.Table)
+		}
+	}
+	return missing, nil
+}
+
 func (db *DatabaseService) GetDB() *gorm.DB {
 	return db.DB.DB
 }
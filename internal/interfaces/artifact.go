@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"github.com/playwright-community/playwright-go"
+
+	"github.com/chandhuDev/JobLoop/internal/models"
+)
+
+type ArtifactClient interface {
+	EnabledFor(companyName string, companyURL string) bool
+	StartTrace(page playwright.Page, companyName string) bool
+	Capture(page playwright.Page, companyName string, stage string, withTrace bool) models.ArtifactPaths
+}
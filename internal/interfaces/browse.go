@@ -1,10 +1,15 @@
 package interfaces
 
 import (
+	"context"
+
 	"github.com/playwright-community/playwright-go"
 )
 
+// BrowserClient opens tabs bound to a context: when ctx is done the tab is
+// closed, which aborts whatever navigation or script is running in it.
 type BrowserClient interface {
-	RunInNewTab() (playwright.Page, error)
+	RunInNewTab(ctx context.Context) (playwright.Page, error)
+	RunInSession(ctx context.Context, session string) (playwright.Page, error)
 	Close()
 }
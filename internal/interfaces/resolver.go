@@ -0,0 +1,21 @@
+package interfaces
+
+import "context"
+
+// URLResolver proposes candidate website URLs for a company name. Candidates
+// are unverified; the search service checks each one before accepting it.
+type URLResolver interface {
+	Name() string
+	Resolve(ctx context.Context, companyName string) ([]string, error)
+}
+
+// SearchEngine returns result URLs for a free-text query, best first.
+type SearchEngine interface {
+	Search(ctx context.Context, query string) ([]string, error)
+}
+
+// URLVerifier fetches a candidate and reports the final site URL, with a
+// confidence between 0 and 1, when the page identifies itself as the company.
+type URLVerifier interface {
+	Verify(ctx context.Context, companyName string, candidate string) (string, float64, bool)
+}
@@ -11,4 +11,9 @@ type ScraperClient struct {
 	Vision          *anthropic.Client
 	DbClient        DatabaseClient
 	NamesChanClient *models.NamesClient
+	Artifacts       ArtifactClient
+	RunID           string
+	Usage           UsageRecorder
+	// Draining is closed once the run stops taking new seeds
+	Draining <-chan struct{}
 }
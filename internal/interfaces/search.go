@@ -1,5 +1,7 @@
 package interfaces
 
+import "context"
+
 type SearchClient interface {
-	SearchKeyword(companyName string, workerId int) (string, error)
+	SearchKeyword(ctx context.Context, companyName string, workerId int) (string, error)
 }
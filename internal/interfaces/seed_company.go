@@ -1,12 +1,14 @@
 package interfaces
 
 import (
+	"context"
+
 	models "github.com/chandhuDev/JobLoop/internal/models"
 )
 
 type SeedCompanyScraper interface {
-	GetSeedCompaniesFromPeerList(scraper *ScraperClient, companyConfig models.SeedCompany)
-	GetSeedCompaniesFromYCombinator(scraper *ScraperClient, companyConfig models.SeedCompany)
-	SeedCompanyConfigs(scraper *ScraperClient)
-	UploadSeedCompanyToChannel(scraper *ScraperClient)
+	GetSeedCompaniesFromPeerList(scraper *ScraperClient, companyConfig *models.SeedCompany, ctx context.Context)
+	GetSeedCompaniesFromYCombinator(ctx context.Context, scraper *ScraperClient, companyConfig *models.SeedCompany)
+	SeedCompanyConfigs(ctx context.Context, scraper *ScraperClient)
+	UploadSeedCompanyToChannel(ctx context.Context, scraper *ScraperClient)
 }
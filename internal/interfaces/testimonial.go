@@ -10,5 +10,5 @@ import (
 
 type TestimonialScraper interface {
 	ScrapeTestimonial(ctx context.Context, scraper *ScraperClient, scChan <-chan models.SeedCompanyResult)
-	scrapeCompany(ctx context.Context, page playwright.Page, scr models.SeedCompanyResult) []string
+	scrapeCompany(ctx context.Context, page playwright.Page, scr models.SeedCompanyResult) []models.TestimonialImage
 }
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"github.com/chandhuDev/JobLoop/internal/models"
+)
+
+// UsageRecorder accounts for Anthropic calls. Allow reports whether a stage
+// may make another call under the configured spend limits.
+type UsageRecorder interface {
+	Allow(stage string) bool
+	Record(usage models.LLMUsage)
+}
+
+// UsageReserver holds the estimated cost of batches whose usage is only
+// known once they are collected, so the spend limits cover them meanwhile.
+type UsageReserver interface {
+	AllowCost(stage string, usd float64) bool
+	Reserve(key string, usd float64)
+	Release(key string)
+}
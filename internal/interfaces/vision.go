@@ -1,9 +1,22 @@
 package interfaces
 
 import (
+	"context"
+
 	models "github.com/chandhuDev/JobLoop/internal/models"
 )
 
+// VisionClient recognises company names in logo images. Recognize returns one
+// result per request, matched by CustomID; a failed image carries its own
+// Error while the returned error means the whole call failed.
 type VisionClient interface {
-	ExtractImageFromText(ImageUrlArrays []string) []models.TestimonialResult
+	Name() string
+	Recognize(ctx context.Context, requests []models.OCRRequest) ([]models.OCRResult, error)
+}
+
+// QueuedVisionClient accepts images for later recognition. Results are not
+// returned to the caller; the provider stores them against the seed company
+// once they arrive, possibly in a later run.
+type QueuedVisionClient interface {
+	Enqueue(ctx context.Context, seedCompanyID uint, requests []models.OCRRequest) error
 }
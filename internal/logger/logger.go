@@ -21,6 +21,8 @@ type Config struct {
 	MaxAge     int 
 	Compress   bool 
 	Console    bool 
+	// ConsoleOut receives console output; nil means stdout
+	ConsoleOut io.Writer
 }
 
 // DefaultConfig returns sensible defaults
@@ -62,8 +64,12 @@ func Init(cfg Config) {
 
 	// Add console output if enabled
 	if cfg.Console {
+		out := cfg.ConsoleOut
+		if out == nil {
+			out = os.Stdout
+		}
 		consoleWriter := zerolog.ConsoleWriter{
-			Out:        os.Stdout,
+			Out:        out,
 			TimeFormat: time.RFC3339,
 		}
 		writers = append(writers, consoleWriter)
@@ -0,0 +1,15 @@
+package models
+
+type Artifact struct {
+	Enabled   bool
+	Trace     bool
+	Dir       string
+	RunID     string
+	Companies map[string]bool
+}
+
+type ArtifactPaths struct {
+	Screenshot string
+	HTML       string
+	Trace      string
+}
@@ -14,4 +14,12 @@ type Options struct {
 	Headless     bool
 	WindowWidth  int
 	WindowHeight int
+	Mode         string
+	ArchiveDir   string
 }
+
+const (
+	BrowserModeLive   = "live"
+	BrowserModeRecord = "record"
+	BrowserModeReplay = "replay"
+)
@@ -0,0 +1,8 @@
+package models
+
+// Stages a run checkpoints when it stops before finishing them
+const (
+	PendingStageJobs         = "jobs"
+	PendingStageTestimonials = "testimonials"
+	PendingStageResolve      = "resolve"
+)
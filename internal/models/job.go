@@ -4,3 +4,13 @@ type Job struct {
 	JobName string
 	JobUrl  string
 }
+
+// ReclassifyResult counts what re-running the job filters changed.
+type ReclassifyResult struct {
+	Scanned         int64 `json:"scanned"`
+	Updated         int64 `json:"updated"`
+	ToEngineering   int64 `json:"to_engineering"`
+	FromEngineering int64 `json:"from_engineering"`
+	MovedToNoise    int64 `json:"moved_to_noise"`
+	DryRun          bool  `json:"dry_run"`
+}
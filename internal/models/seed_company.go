@@ -7,6 +7,7 @@ import (
 
 type SeedCompany struct {
 	Name     string
+	Kind     string
 	URL      string
 	Selector string
 	WaitTime time.Duration
@@ -22,6 +23,20 @@ type SeedCompanyArray struct {
 	Companies   []SeedCompany
 	PWg         *sync.WaitGroup
 	YCWg        *sync.WaitGroup
+	JobWg       *sync.WaitGroup
 	ResultChan  chan SeedCompanyResult
 	Err         ErrorHandler
 }
+
+type MergeResult struct {
+	SurvivorID   uint  `json:"survivor_id"`
+	DuplicateID  uint  `json:"duplicate_id"`
+	JobsMoved    int64 `json:"jobs_moved"`
+	JobsDropped  int64 `json:"jobs_dropped"`
+	Testimonials int64 `json:"testimonials_moved"`
+	Noise        int64 `json:"noise_moved"`
+	Failures     int64 `json:"failures_moved"`
+	OCRItems     int64 `json:"ocr_items_moved"`
+	PendingWork  int64 `json:"pending_work_moved"`
+	LLMUsage     int64 `json:"llm_usage_moved"`
+}
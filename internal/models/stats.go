@@ -0,0 +1,17 @@
+package models
+
+// ScraperStats summarises the scraper's tables.
+type ScraperStats struct {
+	Companies         int64            `json:"companies"`
+	CompaniesWithJobs int64            `json:"companies_with_jobs"`
+	EngineeringJobs   int64            `json:"engineering_jobs"`
+	OtherJobs         int64            `json:"other_jobs"`
+	Noise             int64            `json:"noise"`
+	Testimonials      int64            `json:"testimonials"`
+	Resolutions       int64            `json:"resolutions"`
+	FailuresLastDay   int64            `json:"failures_last_day"`
+	PendingWork       map[string]int64 `json:"pending_work"`
+	QueuedOCRImages   int64            `json:"queued_ocr_images"`
+	OpenOCRBatches    int64            `json:"open_ocr_batches"`
+	SpendTodayUSD     float64          `json:"spend_today_usd"`
+}
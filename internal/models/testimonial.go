@@ -14,7 +14,22 @@ type Testimonial struct {
 type TestimonialImageResult struct {
 	SeedCompanyId uint
 	CompanyName   string
-	URL           []string
+	CompanyURL    string
+	Images        []TestimonialImage
+}
+
+// TestimonialImage is a candidate logo with the text around it that often
+// names the company without any OCR. Data holds an element screenshot for
+// logos that have no downloadable bitmap (inline SVG, CSS background, canvas).
+type TestimonialImage struct {
+	URL       string `json:"src"`
+	Alt       string `json:"alt"`
+	Title     string `json:"title"`
+	AriaLabel string `json:"ariaLabel"`
+	LinkHref  string `json:"linkHref"`
+	Filename  string `json:"filename"`
+	Capture   string `json:"capture"`
+	Data      []byte `json:"-"`
 }
 
 type TestimonialResult struct {
@@ -0,0 +1,33 @@
+package models
+
+const (
+	UsageStageResolve       = "resolve"
+	UsageStageJobExtraction = "job_extraction"
+	UsageStageVision        = "vision"
+)
+
+// TokenUsage mirrors the usage block of a Messages response so batch result
+// lines can be decoded into it directly.
+type TokenUsage struct {
+	InputTokens              int64           `json:"input_tokens"`
+	OutputTokens             int64           `json:"output_tokens"`
+	CacheCreationInputTokens int64           `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int64           `json:"cache_read_input_tokens"`
+	ServerToolUse            ServerToolUsage `json:"server_tool_use"`
+}
+
+type ServerToolUsage struct {
+	WebSearchRequests int64 `json:"web_search_requests"`
+}
+
+// LLMUsage is one Anthropic call to account for. RequestID, when set, names
+// the call uniquely so recording it twice counts it once.
+type LLMUsage struct {
+	RequestID     string
+	Stage         string
+	CompanyName   string
+	SeedCompanyID uint
+	Model         string
+	Batch         bool
+	Usage         TokenUsage
+}
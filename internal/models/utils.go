@@ -5,7 +5,16 @@ type NamesClient struct {
 }
 
 type LinkData struct {
-	URL   string
-	Text  string
-	XPath string
+	URL      string
+	Text     string
+	XPath    string
+	FrameURL string
+	Location string
+	Team     string
+	Source   string
 }
+
+const (
+	JobSourceHeuristic = "heuristic"
+	JobSourceLLM       = "llm"
+)
@@ -11,3 +11,38 @@ type Vision struct {
 	NamesChan     *NamesClient
 	VisionContext context.Context
 }
+
+// RecognizedName is one entry of the structured OCR reply.
+type RecognizedName struct {
+	Name       string  `json:"name"`
+	Confidence float64 `json:"confidence"`
+	IsLogo     bool    `json:"is_logo"`
+}
+
+// OCRRequest is one image sent to a vision provider.
+type OCRRequest struct {
+	CustomID       string
+	SeedCompanyID  uint
+	ImageURL       string
+	ContentHash    string
+	PerceptualHash uint64
+	MediaType      string
+	Data           []byte
+}
+
+type OCRResult struct {
+	CustomID string
+	ImageURL string
+	Names    []RecognizedName
+	Usage    TokenUsage
+	Error    error
+}
+
+const (
+	OCRStatusQueued     = "queued"
+	OCRStatusSubmitting = "submitting"
+	OCRStatusSubmitted  = "submitted"
+	OCRStatusDone       = "done"
+	OCRStatusFailed     = "failed"
+	OCRStatusCollected  = "collected"
+)
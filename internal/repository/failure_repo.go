@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/schema"
+	"gorm.io/gorm"
+)
+
+func CreateScrapeFailure(DB *gorm.DB, scid uint, runID string, stage string, reason string, artifacts models.ArtifactPaths) error {
+	return DB.Create(&schema.ScrapeFailure{
+		SeedCompanyID:  scid,
+		RunID:          runID,
+		Stage:          stage,
+		Reason:         reason,
+		ScreenshotPath: artifacts.Screenshot,
+		HTMLPath:       artifacts.HTML,
+		TracePath:      artifacts.Trace,
+	}).Error
+}
+
+// ListFailedCompanies returns companies with a failure at the stage since the
+// given time that still have no jobs, most recently failed first.
+func ListFailedCompanies(DB *gorm.DB, stage string, since time.Time, runID string, limit int) ([]schema.SeedCompany, error) {
+	failed := DB.Model(&schema.ScrapeFailure{}).
+		Select("seed_company_id, max(created_at) AS failed_at").
+		Where("stage = ? AND created_at >= ?", stage, since).
+		Group("seed_company_id")
+	if runID != "" {
+		failed = failed.Where("run_id = ?", runID)
+	}
+
+	var companies []schema.SeedCompany
+	err := DB.Table("seed_companies").
+		Select("seed_companies.*").
+		Joins("JOIN (?) AS failed ON failed.seed_company_id = seed_companies.id", failed).
+		Where("NOT EXISTS (SELECT 1 FROM jobs WHERE jobs.seed_company_id = seed_companies.id)").
+		Order("failed.failed_at DESC").
+		Limit(limit).
+		Find(&companies).Error
+	return companies, err
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/chandhuDev/JobLoop/internal/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FindCachedImage returns the cached OCR result for an exact image, or for
+// the closest image whose perceptual hash is within maxDistance bits.
+func FindCachedImage(DB *gorm.DB, contentHash string, perceptualHash int64, maxDistance int) (*schema.ImageCache, error) {
+	var entry schema.ImageCache
+
+	err := DB.Where("content_hash = ?", contentHash).First(&entry).Error
+	if err == nil {
+		return &entry, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	err = DB.Where("bit_count((perceptual_hash # ?)::bit(64)) <= ?", perceptualHash, maxDistance).
+		Order(clause.Expr{SQL: "bit_count((perceptual_hash # ?)::bit(64))", Vars: []interface{}{perceptualHash}}).
+		First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func SaveCachedImage(DB *gorm.DB, entry schema.ImageCache) error {
+	return DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "content_hash"}},
+		DoUpdates: clause.AssignmentColumns([]string{"names", "updated_at"}),
+	}).Create(&entry).Error
+}
+
+func TouchCachedImage(DB *gorm.DB, id uint) error {
+	return DB.Model(&schema.ImageCache{}).Where("id = ?", id).UpdateColumn("hits", gorm.Expr("hits + 1")).Error
+}
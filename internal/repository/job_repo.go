@@ -23,7 +23,9 @@ var (
 	}
 )
 
-func isNoise(title, url string) bool {
+// IsNoise reports whether a scanned link is navigation or marketing rather
+// than a job posting.
+func IsNoise(title, url string) bool {
 	if len(strings.TrimSpace(title)) < 3 {
 		return true
 	}
@@ -57,7 +59,7 @@ func UpsertJob(DB *gorm.DB, scid uint, jobs []models.LinkData) error {
 
 	for _, job := range jobs {
 
-		if isNoise(job.Text, job.URL) {
+		if IsNoise(job.Text, job.URL) {
 			noiseRecords = append(noiseRecords, schema.Noise{
 				NoiseUrl:      job.URL,
 				NoiseText:     job.Text,
@@ -77,12 +79,21 @@ func UpsertJob(DB *gorm.DB, scid uint, jobs []models.LinkData) error {
 			otherCount++
 		}
 
+		source := job.Source
+		if source == "" {
+			source = models.JobSourceHeuristic
+		}
+
 		jobRecords = append(jobRecords, schema.Job{
 			SeedCompanyID: scid,
 			JobTitle:      job.Text,
 			JobUrl:        job.URL,
 			IsEngineering: isEng,
 			JobType:       jobType,
+			FrameURL:      job.FrameURL,
+			Location:      job.Location,
+			Team:          job.Team,
+			Source:        source,
 		})
 	}
 
@@ -115,3 +126,72 @@ func UpsertJob(DB *gorm.DB, scid uint, jobs []models.LinkData) error {
 		DoNothing: true,
 	}).Create(&jobRecords).Error
 }
+
+// ReclassifyJobs runs the noise and engineering filters over stored jobs
+// again, so rule changes apply to jobs scraped before them. Jobs that are now
+// noise move to the noise table. With dryRun nothing is written.
+func ReclassifyJobs(DB *gorm.DB, dryRun bool) (*models.ReclassifyResult, error) {
+	result := &models.ReclassifyResult{DryRun: dryRun}
+
+	var batch []schema.Job
+	err := DB.Order("id").FindInBatches(&batch, 500, func(tx *gorm.DB, _ int) error {
+		for _, job := range batch {
+			result.Scanned++
+
+			if IsNoise(job.JobTitle, job.JobUrl) {
+				result.MovedToNoise++
+				if dryRun {
+					continue
+				}
+				if err := moveJobToNoise(DB, job); err != nil {
+					return err
+				}
+				continue
+			}
+
+			isEng := isEngineeringJob(job.JobTitle)
+			jobType := "other"
+			if isEng {
+				jobType = "engineering"
+			}
+			if isEng == job.IsEngineering && jobType == job.JobType {
+				continue
+			}
+
+			result.Updated++
+			if isEng && !job.IsEngineering {
+				result.ToEngineering++
+			} else if !isEng && job.IsEngineering {
+				result.FromEngineering++
+			}
+			if dryRun {
+				continue
+			}
+			if err := DB.Model(&schema.Job{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+				"is_engineering": isEng,
+				"job_type":       jobType,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+
+	return result, err
+}
+
+func moveJobToNoise(DB *gorm.DB, job schema.Job) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "noise_url"}},
+			DoNothing: true,
+		}).Create(&schema.Noise{
+			NoiseUrl:      job.JobUrl,
+			NoiseText:     job.JobTitle,
+			SeedCompanyID: job.SeedCompanyID,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&schema.Job{}, job.ID).Error
+	})
+}
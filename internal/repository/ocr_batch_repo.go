@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/schema"
+	"gorm.io/gorm"
+)
+
+// errOCRItemsTaken rolls back a claim that raced with another submitter
+var errOCRItemsTaken = errors.New("OCR items already claimed")
+
+func QueueOCRItems(DB *gorm.DB, items []schema.OCRBatchItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return DB.Create(&items).Error
+}
+
+// QueuedOCRStats returns how many items wait for a batch and when the oldest
+// of them was queued.
+func QueuedOCRStats(DB *gorm.DB) (int64, time.Time, error) {
+	var stats struct {
+		Count  int64
+		Oldest *time.Time
+	}
+	err := DB.Model(&schema.OCRBatchItem{}).
+		Select("count(*) AS count, min(created_at) AS oldest").
+		Where("status = ?", models.OCRStatusQueued).
+		Scan(&stats).Error
+	if err != nil || stats.Oldest == nil {
+		return stats.Count, time.Time{}, err
+	}
+	return stats.Count, *stats.Oldest, nil
+}
+
+func ListQueuedOCRItems(DB *gorm.DB, limit int) ([]schema.OCRBatchItem, error) {
+	var items []schema.OCRBatchItem
+	err := DB.Where("status = ?", models.OCRStatusQueued).Order("id").Limit(limit).Find(&items).Error
+	return items, err
+}
+
+// ClaimOCRItems moves queued items to submitting before their batch is sent,
+// so two submitters never send the same items. It returns false when another
+// submitter claimed any of them first.
+func ClaimOCRItems(DB *gorm.DB, items []schema.OCRBatchItem) (bool, error) {
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+
+	claimed := false
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&schema.OCRBatchItem{}).
+			Where("id IN ? AND status = ?", ids, models.OCRStatusQueued).
+			Update("status", models.OCRStatusSubmitting)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(ids)) {
+			return errOCRItemsTaken
+		}
+		claimed = true
+		return nil
+	})
+	if errors.Is(err, errOCRItemsTaken) {
+		return false, nil
+	}
+	return claimed, err
+}
+
+// ReleaseOCRItems puts claimed items back in the queue after the batch could
+// not be created.
+func ReleaseOCRItems(DB *gorm.DB, items []schema.OCRBatchItem) error {
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return DB.Model(&schema.OCRBatchItem{}).
+		Where("id IN ? AND status = ?", ids, models.OCRStatusSubmitting).
+		Update("status", models.OCRStatusQueued).Error
+}
+
+// RequeueStaleOCRItems puts items back in the queue that were claimed before
+// the given time but never recorded against a batch, and returns how many.
+func RequeueStaleOCRItems(DB *gorm.DB, claimedBefore time.Time) (int64, error) {
+	result := DB.Model(&schema.OCRBatchItem{}).
+		Where("status = ? AND updated_at < ?", models.OCRStatusSubmitting, claimedBefore).
+		Update("status", models.OCRStatusQueued)
+	return result.RowsAffected, result.Error
+}
+
+// SaveSubmittedOCRBatch records a submitted batch and moves its items out of
+// the queue. The image bytes are dropped since the API now holds them.
+func SaveSubmittedOCRBatch(DB *gorm.DB, batchID string, items []schema.OCRBatchItem) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&schema.OCRBatch{
+			BatchID:      batchID,
+			Status:       models.OCRStatusSubmitted,
+			RequestCount: len(items),
+		}).Error; err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			err := tx.Model(&schema.OCRBatchItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+				"batch_id":  batchID,
+				"custom_id": item.CustomID,
+				"status":    models.OCRStatusSubmitted,
+				"data":      nil,
+			}).Error
+			if err != nil {
+				return fmt.Errorf("update item %d: %w", item.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+func ListOpenOCRBatches(DB *gorm.DB) ([]schema.OCRBatch, error) {
+	var batches []schema.OCRBatch
+	err := DB.Where("status = ?", models.OCRStatusSubmitted).Order("id").Find(&batches).Error
+	return batches, err
+}
+
+func ListOCRBatchItems(DB *gorm.DB, batchID string) ([]schema.OCRBatchItem, error) {
+	var items []schema.OCRBatchItem
+	err := DB.Where("batch_id = ?", batchID).Find(&items).Error
+	return items, err
+}
+
+func CompleteOCRBatchItem(DB *gorm.DB, id uint, names []string, errMsg string) error {
+	status := models.OCRStatusDone
+	if errMsg != "" {
+		status = models.OCRStatusFailed
+	}
+	if names == nil {
+		names = []string{}
+	}
+	return DB.Model(&schema.OCRBatchItem{ID: id}).Select("status", "names", "error").Updates(schema.OCRBatchItem{
+		Status: status,
+		Names:  names,
+		Error:  errMsg,
+	}).Error
+}
+
+func FinishOCRBatch(DB *gorm.DB, id uint, status string, errMsg string) error {
+	now := time.Now()
+	return DB.Model(&schema.OCRBatch{ID: id}).Updates(map[string]interface{}{
+		"status":   status,
+		"error":    errMsg,
+		"ended_at": &now,
+	}).Error
+}
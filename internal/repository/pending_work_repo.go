@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"github.com/chandhuDev/JobLoop/internal/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SavePendingWork checkpoints one unit of work. Work already checkpointed
+// for the same stage and key is kept as is.
+func SavePendingWork(DB *gorm.DB, work schema.PendingWork) error {
+	return DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "stage"}, {Name: "key"}},
+		DoNothing: true,
+	}).Create(&work).Error
+}
+
+// ClaimPendingWork marks all checkpointed work as resumed by runID and
+// returns it, oldest first. Rows stay until CompletePendingWork, so work a
+// crashed run had claimed is claimed again by the next one.
+func ClaimPendingWork(DB *gorm.DB, runID string) ([]schema.PendingWork, error) {
+	var work []schema.PendingWork
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Order("id").Find(&work).Error; err != nil {
+			return err
+		}
+		if len(work) == 0 {
+			return nil
+		}
+		ids := make([]uint, len(work))
+		for i, w := range work {
+			ids[i] = w.ID
+		}
+		return tx.Model(&schema.PendingWork{}).Where("id IN ?", ids).Update("claimed_by", runID).Error
+	})
+	return work, err
+}
+
+// CompletePendingWork removes the checkpoint of a stage that has finished.
+func CompletePendingWork(DB *gorm.DB, stage string, key string) error {
+	return DB.Where("stage = ? AND key = ?", stage, key).Delete(&schema.PendingWork{}).Error
+}
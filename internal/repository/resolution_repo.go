@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/chandhuDev/JobLoop/internal/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetCompanyResolution returns the cached resolution for a company name, or
+// nil when there is none or it has expired.
+func GetCompanyResolution(DB *gorm.DB, companyName string) (*schema.CompanyResolution, error) {
+	var resolution schema.CompanyResolution
+	err := DB.Where("company_name = ?", companyName).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		First(&resolution).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resolution, nil
+}
+
+// SaveCompanyResolution stores a lookup result. Rows corrected by hand are
+// never overwritten by automatic lookups.
+func SaveCompanyResolution(DB *gorm.DB, resolution schema.CompanyResolution) error {
+	return DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "company_name"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"company_url", "confidence", "source", "negative", "resolved_at", "expires_at", "updated_at",
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Eq{Column: clause.Column{Table: "company_resolutions", Name: "reviewed"}, Value: false},
+		}},
+	}).Create(&resolution).Error
+}
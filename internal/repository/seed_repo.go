@@ -1,18 +1,22 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/chandhuDev/JobLoop/internal/logger"
+	"github.com/chandhuDev/JobLoop/internal/models"
 	"github.com/chandhuDev/JobLoop/internal/schema"
 	"gorm.io/gorm"
 )
 
-func CreateSeedCompanyRepository(scn string, scu string) *schema.SeedCompany {
+func CreateSeedCompanyRepository(scn string, scu string, domain string) *schema.SeedCompany {
 	return &schema.SeedCompany{
-		CompanyName: scn,
-		CompanyURL:  scu,
-		Visited:     true,
+		CompanyName:     scn,
+		CompanyURL:      scu,
+		CanonicalDomain: domain,
+		Visited:         true,
 	}
 }
 
@@ -33,17 +37,45 @@ func UpdateSeedCompanyData(scid uint, DB *gorm.DB, flags map[string]interface{},
 		Error
 }
 
+// CreateSeedCompany finds the company by canonical domain, then by URL, and
+// updates it in place; otherwise it inserts. A company found only by domain
+// keeps its name: a different name is logged as a merge candidate rather than
+// silently renaming the stored company.
 func CreateSeedCompany(seedCompany *schema.SeedCompany, DB *gorm.DB) error {
 	var existing schema.SeedCompany
 	existingResult := DB.Where("company_url = ?", seedCompany.CompanyURL).First(&existing)
+	if seedCompany.CanonicalDomain != "" {
+		existingResult = DB.Where("canonical_domain = ?", seedCompany.CanonicalDomain).
+			Or("company_url = ?", seedCompany.CompanyURL).
+			Order("id ASC").
+			First(&existing)
+	}
 
 	if existingResult.Error == nil {
 		// Exists - update and return existing ID
 		seedCompany.ID = existing.ID
-		return DB.Model(&existing).Updates(map[string]interface{}{
-			"visited":      seedCompany.Visited,
-			"company_name": seedCompany.CompanyName,
-		}).Error
+		updates := map[string]interface{}{
+			"visited": seedCompany.Visited,
+		}
+		if existing.CompanyURL == seedCompany.CompanyURL {
+			updates["company_name"] = seedCompany.CompanyName
+		} else if !strings.EqualFold(existing.CompanyName, seedCompany.CompanyName) {
+			logger.Warn().
+				Uint("existing_id", existing.ID).
+				Str("existing_name", existing.CompanyName).
+				Str("existing_url", existing.CompanyURL).
+				Str("name", seedCompany.CompanyName).
+				Str("url", seedCompany.CompanyURL).
+				Str("domain", seedCompany.CanonicalDomain).
+				Msg("merge candidate: company shares a domain with a stored company")
+		}
+		if existing.CanonicalDomain == "" && seedCompany.CanonicalDomain != "" {
+			updates["canonical_domain"] = seedCompany.CanonicalDomain
+		}
+		if seedCompany.RedirectsTo != "" && existing.CompanyURL == seedCompany.CompanyURL {
+			updates["redirects_to"] = seedCompany.RedirectsTo
+		}
+		return DB.Model(&existing).Updates(updates).Error
 	}
 
 	// Doesn't exist - create new
@@ -57,3 +89,137 @@ func CreateSeedCompany(seedCompany *schema.SeedCompany, DB *gorm.DB) error {
 	}
 	return nil
 }
+
+// FindSeedCompanyByDomain returns the company that owns a canonical domain,
+// or nil when none does.
+func FindSeedCompanyByDomain(DB *gorm.DB, domain string) (*schema.SeedCompany, error) {
+	var company schema.SeedCompany
+	err := DB.Where("canonical_domain = ?", domain).First(&company).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+// ListSeedCompaniesWithoutDomain returns companies stored before canonical
+// domains were recorded.
+func ListSeedCompaniesWithoutDomain(DB *gorm.DB) ([]schema.SeedCompany, error) {
+	var companies []schema.SeedCompany
+	err := DB.Where("canonical_domain = '' OR canonical_domain IS NULL").Order("id ASC").Find(&companies).Error
+	return companies, err
+}
+
+// SetSeedCompanyRedirect records the other-domain site a company's URL
+// redirects to, for review.
+func SetSeedCompanyRedirect(DB *gorm.DB, scid uint, redirect string) error {
+	return DB.Model(&schema.SeedCompany{}).Where("id = ?", scid).Update("redirects_to", redirect).Error
+}
+
+// SetSeedCompanyDomain records the canonical domain of a company. When another
+// company already owns the domain, the newer of the two is merged into the
+// older and the survivor's ID is returned.
+func SetSeedCompanyDomain(DB *gorm.DB, scid uint, domain string) (uint, *models.MergeResult, error) {
+	var owner schema.SeedCompany
+	err := DB.Where("canonical_domain = ? AND id <> ?", domain, scid).First(&owner).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return scid, nil, DB.Model(&schema.SeedCompany{}).Where("id = ?", scid).Update("canonical_domain", domain).Error
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	survivor, duplicate := owner.ID, scid
+	if scid < owner.ID {
+		survivor, duplicate = scid, owner.ID
+	}
+
+	result, err := MergeSeedCompanies(DB, survivor, duplicate)
+	if err != nil {
+		return 0, nil, err
+	}
+	return survivor, result, DB.Model(&schema.SeedCompany{}).Where("id = ?", survivor).Update("canonical_domain", domain).Error
+}
+
+// MergeSeedCompanies moves everything that belongs to the duplicate (jobs,
+// testimonial edges, noise, failures, queued OCR images, checkpoints and LLM
+// usage) onto the survivor and deletes the duplicate, in one transaction.
+// Jobs whose title the survivor already has are dropped.
+func MergeSeedCompanies(DB *gorm.DB, survivorID uint, duplicateID uint) (*models.MergeResult, error) {
+	if survivorID == duplicateID {
+		return nil, fmt.Errorf("cannot merge company %d into itself", survivorID)
+	}
+
+	result := &models.MergeResult{SurvivorID: survivorID, DuplicateID: duplicateID}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		var survivor, duplicate schema.SeedCompany
+		if err := tx.First(&survivor, survivorID).Error; err != nil {
+			return fmt.Errorf("survivor %d: %w", survivorID, err)
+		}
+		if err := tx.First(&duplicate, duplicateID).Error; err != nil {
+			return fmt.Errorf("duplicate %d: %w", duplicateID, err)
+		}
+
+		moved := tx.Model(&schema.Job{}).
+			Where("seed_company_id = ?", duplicateID).
+			Where("job_title NOT IN (?)", tx.Model(&schema.Job{}).Select("job_title").Where("seed_company_id = ?", survivorID)).
+			Update("seed_company_id", survivorID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.JobsMoved = moved.RowsAffected
+
+		dropped := tx.Where("seed_company_id = ?", duplicateID).Delete(&schema.Job{})
+		if dropped.Error != nil {
+			return dropped.Error
+		}
+		result.JobsDropped = dropped.RowsAffected
+
+		for _, move := range []struct {
+			model interface{}
+			count *int64
+		}{
+			{&schema.TestimonialCompany{}, &result.Testimonials},
+			{&schema.Noise{}, &result.Noise},
+			{&schema.ScrapeFailure{}, &result.Failures},
+			{&schema.OCRBatchItem{}, &result.OCRItems},
+			{&schema.PendingWork{}, &result.PendingWork},
+			{&schema.LLMUsage{}, &result.LLMUsage},
+		} {
+			res := tx.Model(move.model).Where("seed_company_id = ?", duplicateID).Update("seed_company_id", survivorID)
+			if res.Error != nil {
+				return res.Error
+			}
+			*move.count = res.RowsAffected
+		}
+
+		if err := tx.Delete(&schema.SeedCompany{}, duplicateID).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"testimonial_scraped": survivor.TestimonialScraped || duplicate.TestimonialScraped,
+			"job_scraped":         survivor.JobScraped || duplicate.JobScraped,
+		}
+		if survivor.CanonicalDomain == "" && duplicate.CanonicalDomain != "" {
+			updates["canonical_domain"] = duplicate.CanonicalDomain
+		}
+		return tx.Model(&schema.SeedCompany{}).Where("id = ?", survivorID).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info().
+		Uint("survivor_id", survivorID).
+		Uint("duplicate_id", duplicateID).
+		Int64("jobs_moved", result.JobsMoved).
+		Int64("jobs_dropped", result.JobsDropped).
+		Int64("testimonials_moved", result.Testimonials).
+		Msg("merged seed companies")
+
+	return result, nil
+}
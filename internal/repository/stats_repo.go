@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/schema"
+	"gorm.io/gorm"
+)
+
+// CollectScraperStats counts companies, jobs, failures and the work waiting
+// for the next run.
+func CollectScraperStats(DB *gorm.DB) (*models.ScraperStats, error) {
+	stats := &models.ScraperStats{PendingWork: make(map[string]int64)}
+
+	counts := []struct {
+		query *gorm.DB
+		out   *int64
+	}{
+		{DB.Model(&schema.SeedCompany{}), &stats.Companies},
+		{DB.Model(&schema.Job{}).Distinct("seed_company_id"), &stats.CompaniesWithJobs},
+		{DB.Model(&schema.Job{}).Where("job_type = ?", "engineering"), &stats.EngineeringJobs},
+		{DB.Model(&schema.Job{}).Where("job_type <> ?", "engineering"), &stats.OtherJobs},
+		{DB.Model(&schema.Noise{}), &stats.Noise},
+		{DB.Model(&schema.TestimonialCompany{}), &stats.Testimonials},
+		{DB.Model(&schema.CompanyResolution{}), &stats.Resolutions},
+		{DB.Model(&schema.ScrapeFailure{}).Where("created_at >= ?", time.Now().Add(-24*time.Hour)), &stats.FailuresLastDay},
+		{DB.Model(&schema.OCRBatchItem{}).Where("status = ?", models.OCRStatusQueued), &stats.QueuedOCRImages},
+		{DB.Model(&schema.OCRBatch{}).Where("status = ?", models.OCRStatusSubmitted), &stats.OpenOCRBatches},
+	}
+	for _, c := range counts {
+		if err := c.query.Count(c.out).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	var pending []struct {
+		Stage string
+		Count int64
+	}
+	if err := DB.Model(&schema.PendingWork{}).Select("stage, count(*) AS count").Group("stage").Scan(&pending).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range pending {
+		stats.PendingWork[p.Stage] = p.Count
+	}
+
+	now := time.Now().UTC()
+	spend, err := LLMSpendSince(DB, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return nil, err
+	}
+	stats.SpendTodayUSD = spend
+	return stats, nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/chandhuDev/JobLoop/internal/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func SaveLLMUsage(DB *gorm.DB, usage *schema.LLMUsage) error {
+	return DB.Create(usage).Error
+}
+
+// SaveLLMUsageOnce stores a call with a request ID unless it is already
+// recorded, and reports whether it was new.
+func SaveLLMUsageOnce(DB *gorm.DB, usage *schema.LLMUsage) (bool, error) {
+	result := DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "request_id"}},
+		DoNothing: true,
+	}).Create(usage)
+	return result.RowsAffected > 0, result.Error
+}
+
+// LLMSpendSince sums the estimated cost of every call made since t.
+func LLMSpendSince(DB *gorm.DB, since time.Time) (float64, error) {
+	var total float64
+	err := DB.Model(&schema.LLMUsage{}).
+		Select("COALESCE(SUM(cost_usd), 0)").
+		Where("created_at >= ?", since).
+		Scan(&total).Error
+	return total, err
+}
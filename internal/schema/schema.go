@@ -7,8 +7,13 @@ import (
 type SeedCompany struct {
 	ID uint `gorm:"primaryKey"`
 
-	CompanyName string `gorm:"not null;uniqueIndex"`
-	CompanyURL  string `gorm:"not null;uniqueIndex"`
+	CompanyName     string `gorm:"not null;index"`
+	CompanyURL      string `gorm:"not null;uniqueIndex"`
+	CanonicalDomain string `gorm:"uniqueIndex:idx_seed_companies_canonical_domain,where:canonical_domain <> ''"`
+
+	// Site on another domain that CompanyURL redirects to, such as a rebrand
+	// or an acquirer, kept for review rather than adopted
+	RedirectsTo string `gorm:"not null;default:''"`
 
 	Visited            bool `gorm:"default:false"`
 	TestimonialScraped bool `gorm:"default:false"`
@@ -18,6 +23,7 @@ type SeedCompany struct {
 
 	Testimonials []TestimonialCompany `gorm:"constraint:OnDelete:CASCADE;foreignKey:SeedCompanyID"`
 	Jobs         []Job                `gorm:"constraint:OnDelete:CASCADE;foreignKey:SeedCompanyID"`
+	Failures     []ScrapeFailure      `gorm:"constraint:OnDelete:CASCADE;foreignKey:SeedCompanyID"`
 }
 
 type TestimonialCompany struct {
@@ -37,6 +43,10 @@ type Job struct {
 	JobUrl        string `gorm:"not null"`
 	IsEngineering bool   `json:"is_engineering" gorm:"default:true;index"`
 	JobType       string `json:"job_type" gorm:"default:'unknown'"`
+	FrameURL      string `json:"frame_url"`
+	Location      string `json:"location"`
+	Team          string `json:"team"`
+	Source        string `json:"source" gorm:"default:'heuristic';index"`
 
 	CreatedAt time.Time
 }
@@ -48,3 +58,125 @@ type Noise struct {
 	SeedCompanyID uint      `gorm:"not null;index"`
 	CreatedAt     time.Time `gorm:"autoCreateTime"`
 }
+
+type ScrapeFailure struct {
+	ID uint `gorm:"primaryKey"`
+
+	SeedCompanyID uint   `gorm:"not null;index"`
+	RunID         string `gorm:"not null;index"`
+	Stage         string `gorm:"not null"`
+	Reason        string `gorm:"not null"`
+
+	ScreenshotPath string
+	HTMLPath       string
+	TracePath      string
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+type CompanyResolution struct {
+	ID uint `gorm:"primaryKey"`
+
+	CompanyName string  `json:"company_name" gorm:"type:citext;not null;uniqueIndex"`
+	CompanyURL  string  `json:"company_url"`
+	Confidence  float64 `json:"confidence" gorm:"not null;default:0;index"`
+	Source      string  `json:"source" gorm:"not null"`
+	Negative    bool    `json:"negative" gorm:"default:false;index"`
+	Reviewed    bool    `json:"reviewed" gorm:"default:false"`
+
+	ResolvedAt time.Time  `json:"resolved_at" gorm:"not null"`
+	ExpiresAt  *time.Time `json:"expires_at" gorm:"index"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type ImageCache struct {
+	ID uint `gorm:"primaryKey"`
+
+	ContentHash    string   `json:"content_hash" gorm:"not null;uniqueIndex"`
+	PerceptualHash int64    `json:"perceptual_hash" gorm:"not null;index"`
+	SourceURL      string   `json:"source_url"`
+	Names          []string `json:"names" gorm:"serializer:json"`
+	Hits           int      `json:"hits" gorm:"default:0"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time
+}
+
+// OCRBatch is a Message Batch submitted for logo recognition. It outlives
+// the run that created it so a later run can collect the results.
+type OCRBatch struct {
+	ID uint `gorm:"primaryKey"`
+
+	BatchID      string     `json:"batch_id" gorm:"not null;uniqueIndex"`
+	Status       string     `json:"status" gorm:"not null;default:'submitted';index"`
+	RequestCount int        `json:"request_count"`
+	Error        string     `json:"error"`
+	EndedAt      *time.Time `json:"ended_at"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time
+}
+
+// OCRBatchItem maps one image in a batch back to the seed company it was
+// found on. Queued items have no batch yet and keep the image bytes until
+// they are submitted.
+type OCRBatchItem struct {
+	ID uint `gorm:"primaryKey"`
+
+	BatchID        string   `json:"batch_id" gorm:"index"`
+	CustomID       string   `json:"custom_id" gorm:"index"`
+	SeedCompanyID  uint     `json:"seed_company_id" gorm:"not null;index"`
+	ImageURL       string   `json:"image_url"`
+	ContentHash    string   `json:"content_hash" gorm:"not null"`
+	PerceptualHash int64    `json:"perceptual_hash"`
+	MediaType      string   `json:"media_type"`
+	Data           []byte   `json:"-"`
+	Status         string   `json:"status" gorm:"not null;default:'queued';index"`
+	Names          []string `json:"names" gorm:"serializer:json"`
+	Error          string   `json:"error"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time
+}
+
+// LLMUsage is one Anthropic call with its token counts and estimated cost.
+type LLMUsage struct {
+	ID uint `gorm:"primaryKey"`
+
+	RunID         string  `json:"run_id" gorm:"index"`
+	RequestID     *string `json:"request_id" gorm:"uniqueIndex"`
+	Stage         string  `json:"stage" gorm:"not null;index"`
+	CompanyName   string  `json:"company_name"`
+	SeedCompanyID uint    `json:"seed_company_id" gorm:"index"`
+	Model         string  `json:"model"`
+	Batch         bool    `json:"batch"`
+
+	InputTokens              int64   `json:"input_tokens"`
+	OutputTokens             int64   `json:"output_tokens"`
+	CacheCreationInputTokens int64   `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int64   `json:"cache_read_input_tokens"`
+	WebSearchRequests        int64   `json:"web_search_requests"`
+	CostUSD                  float64 `json:"cost_usd"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime;index"`
+}
+
+func (LLMUsage) TableName() string {
+	return "llm_usage"
+}
+
+// PendingWork is a unit of work a run stopped before finishing. The next run
+// claims these rows and picks the work up again.
+type PendingWork struct {
+	ID uint `gorm:"primaryKey"`
+
+	Stage         string `json:"stage" gorm:"not null;uniqueIndex:idx_pending_stage_key"`
+	Key           string `json:"key" gorm:"not null;uniqueIndex:idx_pending_stage_key"`
+	SeedCompanyID uint   `json:"seed_company_id"`
+	CompanyName   string `json:"company_name"`
+	CompanyURL    string `json:"company_url"`
+	RunID         string `json:"run_id" gorm:"index"`
+	ClaimedBy     string `json:"claimed_by" gorm:"index"` // run resuming the work, until it finishes
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
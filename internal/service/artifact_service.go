@@ -0,0 +1,158 @@
+package service
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	"github.com/chandhuDev/JobLoop/internal/models"
+)
+
+type ArtifactService struct {
+	Artifact *models.Artifact
+}
+
+var slugRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// NewRunID returns a sortable identifier used to group everything a single
+// scraper run produces.
+func NewRunID() string {
+	return time.Now().UTC().Format("20060102T150405Z")
+}
+
+// NewArtifactRecorder reads the artifact settings from the environment.
+// ARTIFACTS_ENABLED turns recording on for the whole run, ARTIFACTS_COMPANIES
+// (comma separated names or hosts) turns it on for selected companies only.
+func NewArtifactRecorder(runID string) *models.Artifact {
+	companies := make(map[string]bool)
+	for _, c := range strings.Split(os.Getenv("ARTIFACTS_COMPANIES"), ",") {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c != "" {
+			companies[c] = true
+		}
+	}
+
+	dir := os.Getenv("ARTIFACTS_DIR")
+	if dir == "" {
+		dir = "./artifacts"
+	}
+
+	return &models.Artifact{
+		Enabled:   os.Getenv("ARTIFACTS_ENABLED") == "true",
+		Trace:     os.Getenv("ARTIFACTS_TRACE") == "true",
+		Dir:       dir,
+		RunID:     runID,
+		Companies: companies,
+	}
+}
+
+func (a *ArtifactService) EnabledFor(companyName string, companyURL string) bool {
+	if a == nil || a.Artifact == nil {
+		return false
+	}
+	if a.Artifact.Enabled {
+		return true
+	}
+	if len(a.Artifact.Companies) == 0 {
+		return false
+	}
+
+	if a.Artifact.Companies[strings.ToLower(strings.TrimSpace(companyName))] {
+		return true
+	}
+	if parsed, err := url.Parse(companyURL); err == nil && parsed.Host != "" {
+		host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+		return a.Artifact.Companies[host]
+	}
+	return false
+}
+
+// StartTrace begins a Playwright trace on the page's context when traces are
+// enabled. It reports whether a trace is running so the caller knows to stop it.
+func (a *ArtifactService) StartTrace(page playwright.Page, companyName string) bool {
+	if a == nil || a.Artifact == nil || !a.Artifact.Trace {
+		return false
+	}
+
+	err := page.Context().Tracing().Start(playwright.TracingStartOptions{
+		Title:       playwright.String(companyName),
+		Screenshots: playwright.Bool(true),
+		Snapshots:   playwright.Bool(true),
+	})
+	if err != nil {
+		logger.Warn().Err(err).Str("company", companyName).Msg("Failed to start trace")
+		return false
+	}
+	return true
+}
+
+// Capture saves a full-page screenshot, the final HTML and, when a trace is
+// running, the trace zip under <dir>/<run>/<company>/. Paths of the files that
+// were written are returned; failures are logged and leave the path empty.
+func (a *ArtifactService) Capture(page playwright.Page, companyName string, stage string, withTrace bool) models.ArtifactPaths {
+	var paths models.ArtifactPaths
+	if a == nil || a.Artifact == nil || page == nil {
+		return paths
+	}
+
+	dir := filepath.Join(a.Artifact.Dir, a.Artifact.RunID, slugify(companyName))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		logger.Error().Err(err).Str("dir", dir).Msg("Failed to create artifact directory")
+		return paths
+	}
+
+	prefix := filepath.Join(dir, slugify(stage))
+
+	screenshotPath := prefix + ".png"
+	if _, err := page.Screenshot(playwright.PageScreenshotOptions{
+		Path:     playwright.String(screenshotPath),
+		FullPage: playwright.Bool(true),
+		Type:     playwright.ScreenshotTypePng,
+	}); err != nil {
+		logger.Warn().Err(err).Str("company", companyName).Msg("Failed to capture screenshot")
+	} else {
+		paths.Screenshot = screenshotPath
+	}
+
+	htmlPath := prefix + ".html"
+	if html, err := page.Content(); err != nil {
+		logger.Warn().Err(err).Str("company", companyName).Msg("Failed to capture HTML")
+	} else if err := os.WriteFile(htmlPath, []byte(html), 0644); err != nil {
+		logger.Warn().Err(err).Str("path", htmlPath).Msg("Failed to write HTML snapshot")
+	} else {
+		paths.HTML = htmlPath
+	}
+
+	if withTrace {
+		tracePath := prefix + "-trace.zip"
+		if err := page.Context().Tracing().Stop(tracePath); err != nil {
+			logger.Warn().Err(err).Str("company", companyName).Msg("Failed to save trace")
+		} else {
+			paths.Trace = tracePath
+		}
+	}
+
+	logger.Info().
+		Str("company", companyName).
+		Str("stage", stage).
+		Str("screenshot", paths.Screenshot).
+		Str("html", paths.HTML).
+		Str("trace", paths.Trace).
+		Msg("Saved failure artifacts")
+
+	return paths
+}
+
+func slugify(s string) string {
+	slug := strings.Trim(slugRegex.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if slug == "" {
+		return "unknown"
+	}
+	return slug
+}
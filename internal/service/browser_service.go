@@ -2,7 +2,13 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/chandhuDev/JobLoop/internal/logger"
 	"github.com/chandhuDev/JobLoop/internal/models"
 	"github.com/playwright-community/playwright-go"
 )
@@ -12,6 +18,17 @@ type BrowserService struct {
 }
 
 func CreateNewBrowser(options models.Options, ctx context.Context) (*BrowserService, error) {
+	switch options.Mode {
+	case "":
+		options.Mode = models.BrowserModeLive
+	case models.BrowserModeLive, models.BrowserModeRecord, models.BrowserModeReplay:
+	default:
+		return nil, fmt.Errorf("unknown browser mode %q", options.Mode)
+	}
+	if options.ArchiveDir == "" {
+		options.ArchiveDir = "./archives"
+	}
+
 	pw, err := playwright.Run()
 	if err != nil {
 		return nil, err
@@ -41,18 +58,106 @@ func CreateNewBrowser(options models.Options, ctx context.Context) (*BrowserServ
 	}, nil
 }
 
-func (b *BrowserService) RunInNewTab() (playwright.Page, error) {
-	page, err := b.Browser.Browser.NewPage(playwright.BrowserNewPageOptions{
+func (b *BrowserService) RunInNewTab(ctx context.Context) (playwright.Page, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	page, err := b.Browser.Browser.NewPage(b.pageOptions())
+	if err != nil {
+		return nil, err
+	}
+	return bindPage(ctx, page), nil
+}
+
+// bindPage closes the tab when ctx is done so blocked Playwright calls return
+// at once. The hook is released when the tab is closed normally.
+func bindPage(ctx context.Context, page playwright.Page) playwright.Page {
+	stop := context.AfterFunc(ctx, func() {
+		page.Close()
+	})
+	page.OnClose(func(playwright.Page) {
+		stop()
+	})
+	return page
+}
+
+// RunInSession opens a tab whose network traffic belongs to a named crawl
+// session. In record mode everything the tab loads is archived to
+// <ArchiveDir>/<session>.har.zip when the tab is closed; in replay mode the tab
+// is served from that archive and requests missing from it are aborted. In
+// live mode it behaves like RunInNewTab.
+func (b *BrowserService) RunInSession(ctx context.Context, session string) (playwright.Page, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	archivePath := filepath.Join(b.Browser.Options.ArchiveDir, slugify(session)+".har.zip")
+
+	switch b.Browser.Options.Mode {
+	case models.BrowserModeRecord:
+		if err := os.MkdirAll(b.Browser.Options.ArchiveDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create archive directory: %w", err)
+		}
+
+		options := b.pageOptions()
+		options.RecordHarPath = playwright.String(archivePath)
+		options.RecordHarContent = playwright.HarContentPolicyAttach
+		options.RecordHarMode = playwright.HarModeFull
+
+		logger.Info().Str("session", session).Str("archive", archivePath).Msg("Recording session")
+		page, err := b.Browser.Browser.NewPage(options)
+		if err != nil {
+			return nil, err
+		}
+		return bindPage(ctx, page), nil
+
+	case models.BrowserModeReplay:
+		if _, err := os.Stat(archivePath); err != nil {
+			return nil, fmt.Errorf("no archive for session %s: %w", session, err)
+		}
+
+		page, err := b.Browser.Browser.NewPage(b.pageOptions())
+		if err != nil {
+			return nil, err
+		}
+		if err := page.RouteFromHAR(archivePath, playwright.PageRouteFromHAROptions{
+			NotFound: playwright.HarNotFoundAbort,
+		}); err != nil {
+			page.Close()
+			return nil, fmt.Errorf("failed to route session %s from archive: %w", session, err)
+		}
+
+		logger.Info().Str("session", session).Str("archive", archivePath).Msg("Replaying session")
+		return bindPage(ctx, page), nil
+
+	default:
+		return b.RunInNewTab(ctx)
+	}
+}
+
+// sessionName keys a crawl session by stage and company host, so the job and
+// testimonial crawls of one company can be recorded side by side.
+func sessionName(stage string, companyURL string) string {
+	if !strings.HasPrefix(companyURL, "http://") && !strings.HasPrefix(companyURL, "https://") {
+		companyURL = "https://" + companyURL
+	}
+
+	host := companyURL
+	if parsed, err := url.Parse(companyURL); err == nil && parsed.Host != "" {
+		host = strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	}
+	return stage + "-" + host
+}
+
+func (b *BrowserService) pageOptions() playwright.BrowserNewPageOptions {
+	return playwright.BrowserNewPageOptions{
 		UserAgent: playwright.String("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
 		Viewport: &playwright.Size{
 			Width:  b.Browser.Options.WindowWidth,
 			Height: b.Browser.Options.WindowHeight,
 		},
-	})
-	if err != nil {
-		return nil, err
 	}
-	return page, nil
 }
 
 func (b *BrowserService) Close() {
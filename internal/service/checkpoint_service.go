@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/chandhuDev/JobLoop/internal/interfaces"
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	"github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/repository"
+	"github.com/chandhuDev/JobLoop/internal/schema"
+)
+
+/* ================= DRAIN ================= */
+
+// ShutdownGracePeriod is how long in-flight companies may keep running after
+// a stop signal (SHUTDOWN_GRACE_PERIOD, default 60s) before they are cancelled
+// and checkpointed.
+func ShutdownGracePeriod() time.Duration {
+	return envDuration("SHUTDOWN_GRACE_PERIOD", 60*time.Second)
+}
+
+// draining reports whether the run has stopped taking new seeds.
+func draining(scraper *interfaces.ScraperClient) bool {
+	select {
+	case <-scraper.Draining:
+		return true
+	default:
+		return false
+	}
+}
+
+// intakeContext is cancelled once the run starts draining, for work that
+// only discovers new seeds.
+func intakeContext(ctx context.Context, scraper *interfaces.ScraperClient) (context.Context, context.CancelFunc) {
+	intake, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-scraper.Draining:
+			cancel()
+		case <-intake.Done():
+		}
+	}()
+	return intake, cancel
+}
+
+/* ================= CHECKPOINT ================= */
+
+// checkpointCompany saves a company stage the run did not finish. It runs
+// while the run context is already cancelled, so it never uses it.
+func checkpointCompany(scraper *interfaces.ScraperClient, stage string, company models.SeedCompanyResult) {
+	if scraper.DbClient == nil || company.CompanyURL == "" {
+		return
+	}
+
+	err := repository.SavePendingWork(scraper.DbClient.GetDB(), schema.PendingWork{
+		Stage:         stage,
+		Key:           company.CompanyURL,
+		SeedCompanyID: company.SeedCompanyId,
+		CompanyName:   company.CompanyName,
+		CompanyURL:    company.CompanyURL,
+		RunID:         scraper.RunID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Str("stage", stage).Str("company", company.CompanyName).Msg("Failed to checkpoint unfinished work")
+		return
+	}
+	logger.Info().Str("stage", stage).Str("company", company.CompanyName).Msg("Checkpointed unfinished work")
+}
+
+func checkpointName(scraper *interfaces.ScraperClient, name string) {
+	if scraper.DbClient == nil || name == "" {
+		return
+	}
+
+	err := repository.SavePendingWork(scraper.DbClient.GetDB(), schema.PendingWork{
+		Stage:       models.PendingStageResolve,
+		Key:         name,
+		CompanyName: name,
+		RunID:       scraper.RunID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Str("name", name).Msg("Failed to checkpoint unresolved name")
+	}
+}
+
+// completeCheckpoint removes the checkpoint of a finished stage, so work
+// resumed from an earlier run is dropped only once it is actually done.
+func completeCheckpoint(scraper *interfaces.ScraperClient, stage string, key string) {
+	if scraper.DbClient == nil || key == "" {
+		return
+	}
+
+	if err := repository.CompletePendingWork(scraper.DbClient.GetDB(), stage, key); err != nil {
+		logger.Warn().Err(err).Str("stage", stage).Str("key", key).Msg("Failed to clear finished checkpoint")
+	}
+}
+
+// CheckpointNames saves the names still waiting to be resolved. Call it once
+// nothing else reads the names channel.
+func CheckpointNames(scraper *interfaces.ScraperClient) {
+	count := 0
+	for {
+		select {
+		case name, ok := <-scraper.NamesChanClient.NamesChan:
+			if !ok {
+				return
+			}
+			checkpointName(scraper, name)
+			count++
+		default:
+			if count > 0 {
+				logger.Info().Int("count", count).Msg("Checkpointed unresolved names")
+			}
+			return
+		}
+	}
+}
+
+/* ================= RESUME ================= */
+
+// resumePendingWork hands the work checkpointed by earlier runs back to the
+// stages that stopped before finishing it. Claimed rows are only removed as
+// each stage finishes, so a run that dies mid-way leaves them for the next.
+func (s *SeedCompanyService) resumePendingWork(ctx context.Context, scraper *interfaces.ScraperClient) {
+	if scraper.DbClient == nil {
+		return
+	}
+
+	work, err := repository.ClaimPendingWork(scraper.DbClient.GetDB().WithContext(ctx), scraper.RunID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to load checkpointed work")
+		return
+	}
+	if len(work) == 0 {
+		return
+	}
+	logger.Info().Int("count", len(work)).Msg("Resuming work checkpointed by a previous run")
+
+	var jobs []models.SeedCompanyResult
+	var names []string
+	for _, w := range work {
+		company := models.SeedCompanyResult{
+			CompanyName:   w.CompanyName,
+			CompanyURL:    w.CompanyURL,
+			SeedCompanyId: w.SeedCompanyID,
+		}
+
+		switch w.Stage {
+		case models.PendingStageJobs:
+			jobs = append(jobs, company)
+		case models.PendingStageTestimonials:
+			select {
+			case s.SeedCompany.ResultChan <- company:
+			case <-ctx.Done():
+				return
+			}
+		case models.PendingStageResolve:
+			names = append(names, w.CompanyName)
+		}
+	}
+
+	// Resumed job scrapes run one at a time next to the new ones
+	if len(jobs) > 0 {
+		s.SeedCompany.JobWg.Add(1)
+		go func() {
+			defer s.SeedCompany.JobWg.Done()
+			for _, company := range jobs {
+				if ctx.Err() != nil {
+					return
+				}
+				s.scrapeCompanyJobs(ctx, scraper, company)
+			}
+		}()
+	}
+
+	// Resumed names are resolved here since the crawl may have no search
+	// workers reading the names channel
+	if len(names) > 0 && scraper.Search != nil {
+		s.SeedCompany.JobWg.Add(1)
+		go func() {
+			defer s.SeedCompany.JobWg.Done()
+			for _, name := range names {
+				if ctx.Err() != nil || !s.resolveName(ctx, scraper, name, -1) {
+					return
+				}
+			}
+		}()
+	}
+}
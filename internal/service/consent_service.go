@@ -0,0 +1,211 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+
+	"github.com/chandhuDev/JobLoop/internal/logger"
+)
+
+/* ================= CONFIG ================= */
+
+var (
+	// consentPlatformSelectors maps well-known consent management platforms to
+	// the buttons that dismiss their banners. Accept buttons come first because
+	// some CMPs keep blocking embeds (ATS iframes included) after a rejection.
+	consentPlatformSelectors = []consentPlatform{
+		{"onetrust", "#onetrust-accept-btn-handler"},
+		{"onetrust", "#onetrust-reject-all-handler"},
+		{"onetrust", ".onetrust-close-btn-handler"},
+		{"cookiebot", "#CybotCookiebotDialogBodyLevelButtonLevelOptinAllowAll"},
+		{"cookiebot", "#CybotCookiebotDialogBodyButtonAccept"},
+		{"cookiebot", "#CybotCookiebotDialogBodyButtonDecline"},
+		{"didomi", "#didomi-notice-agree-button"},
+		{"didomi", "#didomi-notice-disagree-button"},
+		{"usercentrics", "[data-testid='uc-accept-all-button']"},
+		{"usercentrics", "[data-testid='uc-deny-all-button']"},
+		{"trustarc", "#truste-consent-button"},
+		{"trustarc", ".truste_popframe .call"},
+		{"trustarc", "#consent_prompt_submit"},
+		{"quantcast", ".qc-cmp2-summary-buttons button[mode='primary']"},
+		{"cookieyes", ".cky-btn-accept"},
+		{"osano", ".osano-cm-accept-all"},
+	}
+
+	// consentButtonTexts are matched against the full, normalised label of
+	// buttons inside something that looks like a consent dialog.
+	consentButtonTexts = []string{
+		// English
+		"accept all", "accept all cookies", "accept cookies", "allow all", "allow all cookies",
+		"allow cookies", "agree and close", "reject all", "decline all", "reject", "decline", "only necessary", "necessary only",
+		// German
+		"alle akzeptieren", "akzeptieren", "alle cookies akzeptieren", "zustimmen", "einverstanden",
+		"alle ablehnen", "ablehnen", "nur notwendige",
+		// French
+		"tout accepter", "accepter", "accepter tout", "j'accepte", "accepter et fermer",
+		"tout refuser", "refuser", "continuer sans accepter",
+		// Spanish
+		"aceptar todo", "aceptar todas", "aceptar", "acepto", "rechazar todo", "rechazar",
+		// Portuguese
+		"aceitar todos", "aceitar tudo", "aceitar", "concordo", "rejeitar todos", "rejeitar",
+		// Italian
+		"accetta tutto", "accetta tutti", "accetta", "accetto", "rifiuta tutto", "rifiuta",
+		// Dutch
+		"alles accepteren", "accepteren", "akkoord", "alles weigeren", "weigeren",
+		// Nordic / Polish
+		"godkänn alla", "acceptera alla", "accepter alle", "hyväksy kaikki", "zaakceptuj wszystkie", "akceptuję",
+		// Japanese
+		"すべて同意", "同意する", "同意", "すべて許可", "すべて拒否",
+	}
+
+	// consentBareTexts say nothing about cookies, so they are only clicked
+	// inside the container of a known consent platform
+	consentBareTexts = []string{"accept", "i agree", "agree", "got it", "ok", "okay"}
+
+	// consentContainers are the banner roots of known consent platforms
+	consentContainers = []string{
+		"#onetrust-consent-sdk", "#onetrust-banner-sdk", "#CybotCookiebotDialog", "#didomi-host",
+		"#didomi-popup", "#truste-consent-track", "#truste-consent-content", ".truste_box_overlay",
+		"#usercentrics-root", ".qc-cmp2-container", ".cky-consent-container", ".osano-cm-window",
+	}
+
+	consentWait = 500 * time.Millisecond
+)
+
+type consentPlatform struct {
+	Name     string `json:"name"`
+	Selector string `json:"selector"`
+}
+
+/* ================= MAIN ================= */
+
+// dismissConsentBanners looks for a cookie-consent overlay in every frame of
+// the page and clicks its accept/reject button. It is cheap to call when no
+// banner is present, so callers run it after each navigation.
+func dismissConsentBanners(page playwright.Page) bool {
+	if page == nil {
+		return false
+	}
+
+	js := fmt.Sprintf(`
+	() => {
+		const PLATFORMS = %s;
+		const TEXTS = %s;
+		const BARE_TEXTS = %s;
+		const CONTAINERS = %s;
+
+		const isVisible = el => {
+			if (!el || !el.isConnected) return false;
+			const s = window.getComputedStyle(el);
+			if (!s || s.display === 'none' || s.visibility === 'hidden' || s.opacity === '0') return false;
+			const r = el.getBoundingClientRect();
+			return r.width > 0 && r.height > 0;
+		};
+
+		// Collect the document plus every open shadow root (Usercentrics renders
+		// its banner inside #usercentrics-root's shadow DOM).
+		const roots = [document];
+		const stack = [document.documentElement];
+		while (stack.length) {
+			const el = stack.pop();
+			if (!el) continue;
+			if (el.shadowRoot) {
+				roots.push(el.shadowRoot);
+				stack.push(...el.shadowRoot.children);
+			}
+			stack.push(...el.children);
+		}
+
+		const query = sel => {
+			for (const root of roots) {
+				let el = null;
+				try { el = root.querySelector(sel); } catch { continue; }
+				if (el && isVisible(el)) return el;
+			}
+			return null;
+		};
+
+		for (const p of PLATFORMS) {
+			const el = query(p.selector);
+			if (el) {
+				el.click();
+				return p.name;
+			}
+		}
+
+		// Walks up through shadow hosts too, which closest() does not
+		const ancestors = function* (el, limit) {
+			let cur = el;
+			for (let depth = 0; cur && depth < limit; depth++) {
+				yield cur;
+				cur = cur.parentElement || cur.getRootNode?.().host;
+			}
+		};
+
+		const inPlatform = el => {
+			for (const cur of ancestors(el, 32)) {
+				if (CONTAINERS.some(sel => { try { return cur.matches?.(sel); } catch { return false; } })) return true;
+			}
+			return false;
+		};
+
+		const CONTAINER_HINTS = ['cookie', 'consent', 'gdpr', 'privacy'];
+		const looksLikeConsent = el => {
+			for (const cur of ancestors(el, 8)) {
+				const id = String(cur.id || '').toLowerCase();
+				const cls = String(cur.className || '').toLowerCase();
+				const role = String(cur.getAttribute?.('role') || '').toLowerCase();
+				const label = String(cur.getAttribute?.('aria-label') || '').toLowerCase();
+				if (CONTAINER_HINTS.some(h => id.includes(h) || cls.includes(h) || label.includes(h))) return true;
+				if ((role === 'dialog' || role === 'alertdialog') && /cookie|consent|datenschutz|privacidad|confidentialité/i.test(cur.innerText || '')) return true;
+			}
+			return false;
+		};
+
+		const normalise = t => String(t || '').replace(/\s+/g, ' ').trim().toLowerCase();
+
+		const clickLabelled = (texts, accepts) => {
+			for (const text of texts) {
+				for (const root of roots) {
+					for (const el of root.querySelectorAll('button, a[role="button"], [role="button"], input[type="button"], input[type="submit"]')) {
+						const label = normalise(el.innerText || el.value || el.getAttribute('aria-label'));
+						if (label !== text) continue;
+						if (!isVisible(el) || !accepts(el)) continue;
+						el.click();
+						return text;
+					}
+				}
+			}
+			return null;
+		};
+
+		const clicked = clickLabelled(TEXTS, looksLikeConsent) || clickLabelled(BARE_TEXTS, inPlatform);
+		if (clicked) return 'generic:' + clicked;
+
+		return null;
+	}
+	`, toJSON(consentPlatformSelectors), toJSArray(consentButtonTexts), toJSArray(consentBareTexts), toJSArray(consentContainers))
+
+	dismissed := false
+	for _, frame := range page.Frames() {
+		res, err := frame.Evaluate(js)
+		if err != nil || res == nil {
+			continue
+		}
+		cmp, ok := res.(string)
+		if !ok || cmp == "" {
+			continue
+		}
+
+		logger.Info().Str("cmp", cmp).Str("frame_url", frame.URL()).Msg("Dismissed cookie consent banner")
+		dismissed = true
+		break
+	}
+
+	if dismissed {
+		page.WaitForTimeout(float64(consentWait.Milliseconds()))
+	}
+	return dismissed
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	"github.com/chandhuDev/JobLoop/internal/repository"
+)
+
+var (
+	canonicalClient = &http.Client{Timeout: 10 * time.Second}
+
+	// Hosts whose subdomains are unrelated sites (acme.github.io). The public
+	// suffix list covers some of them, not all.
+	sharedHosts = []string{
+		"github.io", "gitlab.io", "notion.site", "super.site", "vercel.app", "netlify.app", "pages.dev",
+		"herokuapp.com", "webflow.io", "framer.website", "framer.ai", "wixsite.com", "squarespace.com",
+		"carrd.co", "substack.com", "wordpress.com", "blogspot.com", "gitbook.io",
+	}
+
+	// Hosts that serve unrelated sites under a path (sites.google.com/view/acme)
+	sharedPathHosts = []string{"sites.google.com"}
+
+	// Path segments that identify a site on a shared path host
+	sharedPathSegments = 2
+)
+
+// CanonicalCompanyURL normalises a company URL ("acme.com/?ref=yc",
+// "https://www.acme.com/") to its site root and returns that root with its
+// company domain: the registrable domain, or the full host on a shared host.
+// Redirects are followed only within the same domain, so a startup
+// redirecting to its acquirer keeps its own identity; a redirect elsewhere,
+// such as a rebrand, is returned as the third value for review. A directory
+// page (linkedin.com/company/x) is returned without its query and with no
+// domain, since the directory's domain does not identify the company.
+func CanonicalCompanyURL(ctx context.Context, raw string) (string, string, string) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", "", ""
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Hostname() == "" {
+		return raw, "", ""
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if isDirectoryHost(host) {
+		return siteRoot(parsed) + strings.TrimSuffix(parsed.EscapedPath(), "/"), "", ""
+	}
+	if path := sharedSitePath(parsed); path != "" {
+		return siteRoot(parsed) + path, strings.TrimPrefix(host, "www.") + path, ""
+	}
+
+	siteURL := siteRoot(parsed)
+	domain := companyDomain(host)
+	redirect := ""
+
+	req, err := http.NewRequestWithContext(ctx, "GET", siteURL, nil)
+	if err == nil {
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+		if resp, err := canonicalClient.Do(req); err == nil {
+			resp.Body.Close()
+			final := resp.Request.URL
+			finalDomain := companyDomain(strings.ToLower(final.Hostname()))
+			switch {
+			case resp.StatusCode >= 400 || finalDomain == "":
+			case finalDomain == domain:
+				siteURL = siteRoot(final)
+			default:
+				redirect = siteRoot(final) + strings.TrimSuffix(final.EscapedPath(), "/")
+				logger.Info().Str("url", siteURL).Str("redirect", redirect).Msg("company URL redirects to another domain, keeping its own and recording the redirect")
+			}
+		} else {
+			logger.Debug().Err(err).Str("url", siteURL).Msg("could not follow company URL redirects")
+		}
+	}
+
+	return siteURL, domain, redirect
+}
+
+// companyDomain is the registrable domain of host, or the host itself (without
+// www.) when it is a subdomain of a shared host.
+func companyDomain(host string) string {
+	host = strings.TrimPrefix(strings.TrimSuffix(strings.ToLower(host), "."), "www.")
+	for _, shared := range sharedHosts {
+		if strings.HasSuffix(host, "."+shared) {
+			return host
+		}
+	}
+	return registrableDomain(host)
+}
+
+// sharedSitePath returns the leading path segments that identify a site on a
+// shared path host ("/view/acme"), or "" for other hosts.
+func sharedSitePath(u *url.URL) string {
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	for _, shared := range sharedPathHosts {
+		if host != shared {
+			continue
+		}
+		var segments []string
+		for _, seg := range strings.Split(u.EscapedPath(), "/") {
+			if seg != "" && len(segments) < sharedPathSegments {
+				segments = append(segments, strings.ToLower(seg))
+			}
+		}
+		if len(segments) > 0 {
+			return "/" + strings.Join(segments, "/")
+		}
+	}
+	return ""
+}
+
+func siteRoot(u *url.URL) string {
+	scheme := u.Scheme
+	if scheme != "http" {
+		scheme = "https"
+	}
+	return scheme + "://" + strings.ToLower(u.Host)
+}
+
+// CanonicalizeSeedCompanies backfills canonical domains for companies stored
+// without one, merging companies that turn out to share a domain and
+// recording redirects to other domains.
+func CanonicalizeSeedCompanies(ctx context.Context, DB *gorm.DB, dryRun bool) (int, int, error) {
+	DB = DB.WithContext(ctx)
+
+	companies, err := repository.ListSeedCompaniesWithoutDomain(DB)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	updated, merged := 0, 0
+	for _, company := range companies {
+		if err := ctx.Err(); err != nil {
+			return updated, merged, err
+		}
+
+		_, domain, redirect := CanonicalCompanyURL(ctx, company.CompanyURL)
+		if domain == "" {
+			logger.Warn().Uint("id", company.ID).Str("url", company.CompanyURL).Msg("could not derive canonical domain")
+			continue
+		}
+
+		if dryRun {
+			logger.Info().Uint("id", company.ID).Str("url", company.CompanyURL).Str("domain", domain).Str("redirect", redirect).Msg("would set canonical domain")
+			updated++
+			continue
+		}
+
+		survivor, result, err := repository.SetSeedCompanyDomain(DB, company.ID, domain)
+		if err != nil {
+			logger.Error().Err(err).Uint("id", company.ID).Str("domain", domain).Msg("failed to set canonical domain")
+			continue
+		}
+		if redirect != "" {
+			if err := repository.SetSeedCompanyRedirect(DB, survivor, redirect); err != nil {
+				logger.Warn().Err(err).Uint("id", survivor).Str("redirect", redirect).Msg("failed to record company redirect")
+			}
+		}
+		updated++
+		if result != nil {
+			merged++
+		}
+	}
+
+	return updated, merged, nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// redirectTransport answers every request locally, redirecting the URLs in
+// the table and serving an empty page for the rest.
+type redirectTransport map[string]string
+
+func (r redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}
+	if location, ok := r[req.URL.String()]; ok {
+		resp.StatusCode = http.StatusMovedPermanently
+		resp.Header.Set("Location", location)
+	}
+	return resp, nil
+}
+
+func TestCanonicalCompanyURL(t *testing.T) {
+	original := canonicalClient
+	defer func() { canonicalClient = original }()
+	canonicalClient = &http.Client{
+		Timeout: time.Second,
+		Transport: redirectTransport{
+			"https://acme.com":      "https://www.acme.com/en/",
+			"https://startup.io":    "https://acquirer.com/blog/welcome-startup",
+			"https://shop.acme.com": "https://acme.com/shop",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		raw          string
+		wantURL      string
+		wantDomain   string
+		wantRedirect string
+	}{
+		{"bare domain with query", "acme.org/?ref=yc", "https://acme.org", "acme.org", ""},
+		{"trailing slash and case", "https://WWW.Acme.org/", "https://www.acme.org", "acme.org", ""},
+		{"same-domain redirect", "https://acme.com", "https://www.acme.com", "acme.com", ""},
+		{"subdomain redirect to apex", "https://shop.acme.com/", "https://acme.com", "acme.com", ""},
+		{"cross-domain redirect recorded", "https://startup.io", "https://startup.io", "startup.io", "https://acquirer.com/blog/welcome-startup"},
+		{"directory page", "https://www.linkedin.com/company/acme/?trk=x", "https://www.linkedin.com/company/acme", "", ""},
+		{"public suffix", "https://acme.co.uk/about", "https://acme.co.uk", "acme.co.uk", ""},
+		{"shared host keeps full host", "https://acme.super.site/", "https://acme.super.site", "acme.super.site", ""},
+		{"shared host in the public suffix list", "https://acme.github.io/about", "https://acme.github.io", "acme.github.io", ""},
+		{"shared path host keeps its path", "https://sites.google.com/view/Acme/home?authuser=0", "https://sites.google.com/view/acme", "sites.google.com/view/acme", ""},
+		{"empty", "  ", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotURL, gotDomain, gotRedirect := CanonicalCompanyURL(context.Background(), tt.raw)
+			if gotURL != tt.wantURL || gotDomain != tt.wantDomain || gotRedirect != tt.wantRedirect {
+				t.Errorf("CanonicalCompanyURL(%q) = (%q, %q, %q), want (%q, %q, %q)",
+					tt.raw, gotURL, gotDomain, gotRedirect, tt.wantURL, tt.wantDomain, tt.wantRedirect)
+			}
+		})
+	}
+}
+
+func TestCompanyDomainSeparatesSharedHosts(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"www.acme.com", "acme.com"},
+		{"careers.acme.com", "acme.com"},
+		{"acme.squarespace.com", "acme.squarespace.com"},
+		{"globex.squarespace.com", "globex.squarespace.com"},
+		{"www.acme.wordpress.com", "acme.wordpress.com"},
+		{"squarespace.com", "squarespace.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := companyDomain(tt.host); got != tt.want {
+				t.Errorf("companyDomain(%q) = %q, want %q", tt.host, got, tt.want)
+			}
+		})
+	}
+}
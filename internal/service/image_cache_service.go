@@ -0,0 +1,241 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math/bits"
+	"net/http"
+	"strings"
+	"time"
+
+	_ "golang.org/x/image/webp"
+	"gorm.io/gorm"
+
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	"github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/repository"
+	"github.com/chandhuDev/JobLoop/internal/schema"
+)
+
+/* ================= CONFIG ================= */
+
+var (
+	imageClient = &http.Client{Timeout: 15 * time.Second}
+
+	ocrMediaTypes = map[string]bool{
+		"image/png": true, "image/jpeg": true, "image/gif": true, "image/webp": true,
+	}
+)
+
+const (
+	maxImageBytes = 5 << 20
+
+	// dHash bits two renderings of the same logo may differ by (resized,
+	// recompressed, slightly different padding)
+	perceptualMatchDistance = 4
+)
+
+// ocrImage is a downloaded candidate image, keyed by the SHA-256 of its bytes
+// and by a 64-bit difference hash of its pixels.
+type ocrImage struct {
+	URL            string
+	ContentHash    string
+	PerceptualHash uint64
+	MediaType      string
+	Data           []byte
+}
+
+/* ================= LOADING ================= */
+
+// loadOCRImage returns the candidate's element screenshot when the testimonial
+// scraper captured one, otherwise downloads its bitmap once. SVG files are not
+// downloaded because the vision API only accepts bitmaps; the scraper captures
+// them from the page instead.
+func loadOCRImage(ctx context.Context, candidate models.TestimonialImage) (*ocrImage, error) {
+	if candidate.Data != nil {
+		source := candidate.URL
+		if source == "" {
+			source = "inline:" + candidate.Capture
+		}
+		return newOCRImage(source, candidate.Data)
+	}
+
+	if getExtFromURL(candidate.URL) == ".svg" {
+		return nil, fmt.Errorf("svg was not captured from the page")
+	}
+
+	data, err := downloadImage(ctx, candidate.URL)
+	if err != nil {
+		return nil, err
+	}
+	if isSVG(data) {
+		return nil, fmt.Errorf("svg was not captured from the page")
+	}
+
+	return newOCRImage(candidate.URL, data)
+}
+
+func newOCRImage(url string, data []byte) (*ocrImage, error) {
+	mediaType := http.DetectContentType(data)
+	if !ocrMediaTypes[mediaType] {
+		return nil, fmt.Errorf("unsupported image type %s", mediaType)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return &ocrImage{
+		URL:            url,
+		ContentHash:    hex.EncodeToString(sum[:]),
+		PerceptualHash: differenceHash(decoded),
+		MediaType:      mediaType,
+		Data:           data,
+	}, nil
+}
+
+func downloadImage(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "image/avif,image/webp,image/png,image/svg+xml,image/*;q=0.8")
+
+	resp, err := imageClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("image returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImageBytes {
+		return nil, fmt.Errorf("image larger than %d bytes", maxImageBytes)
+	}
+	return data, nil
+}
+
+func isSVG(data []byte) bool {
+	head := strings.ToLower(string(data[:min(len(data), 512)]))
+	return strings.Contains(head, "<svg")
+}
+
+/* ================= HASHING ================= */
+
+// differenceHash shrinks the image to 9x8 grey cells, compositing transparent
+// pixels onto white the way logos are usually shown, and sets one bit per
+// cell that is darker than its right-hand neighbour.
+func differenceHash(img image.Image) uint64 {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w == 0 || h == 0 {
+		return 0
+	}
+
+	var cells [8][9]float64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 9; x++ {
+			x0, x1 := bounds.Min.X+x*w/9, bounds.Min.X+(x+1)*w/9
+			y0, y1 := bounds.Min.Y+y*h/8, bounds.Min.Y+(y+1)*h/8
+			x1, y1 = max(x1, x0+1), max(y1, y0+1)
+
+			var sum float64
+			var n int
+			for py := y0; py < y1; py++ {
+				for px := x0; px < x1; px++ {
+					r, g, b, a := img.At(px, py).RGBA()
+					white := float64(0xffff - a)
+					sum += 0.299*(float64(r)+white) + 0.587*(float64(g)+white) + 0.114*(float64(b)+white)
+					n++
+				}
+			}
+			cells[y][x] = sum / float64(n)
+		}
+	}
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			if cells[y][x] < cells[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// hasPerceptualDetail reports whether a hash carries enough structure to be
+// compared; flat or nearly flat images all hash to about the same value.
+func hasPerceptualDetail(hash uint64) bool {
+	ones := bits.OnesCount64(hash)
+	return ones >= 4 && ones <= 60
+}
+
+/* ================= CACHE ================= */
+
+// cachedImageNames returns the names recorded for this image or a
+// perceptually identical one. A perceptual hit is stored under the new
+// content hash so the next lookup is exact.
+func cachedImageNames(DB *gorm.DB, img *ocrImage) ([]string, bool) {
+	if DB == nil {
+		return nil, false
+	}
+
+	distance := perceptualMatchDistance
+	if !hasPerceptualDetail(img.PerceptualHash) {
+		distance = -1
+	}
+
+	entry, err := repository.FindCachedImage(DB, img.ContentHash, int64(img.PerceptualHash), distance)
+	if err != nil {
+		logger.Warn().Err(err).Str("url", img.URL).Msg("image cache lookup failed")
+		return nil, false
+	}
+	if entry == nil {
+		return nil, false
+	}
+
+	repository.TouchCachedImage(DB, entry.ID)
+	if entry.ContentHash != img.ContentHash {
+		logger.Debug().Str("url", img.URL).Str("matched", entry.SourceURL).Msg("perceptual image cache hit")
+		cacheImageNames(DB, img, entry.Names)
+	}
+	return entry.Names, true
+}
+
+func cacheImageNames(DB *gorm.DB, img *ocrImage, names []string) {
+	if DB == nil {
+		return
+	}
+	if names == nil {
+		names = []string{}
+	}
+
+	err := repository.SaveCachedImage(DB, schema.ImageCache{
+		ContentHash:    img.ContentHash,
+		PerceptualHash: int64(img.PerceptualHash),
+		SourceURL:      img.URL,
+		Names:          names,
+	})
+	if err != nil {
+		logger.Warn().Err(err).Str("url", img.URL).Msg("failed to cache image names")
+	}
+}
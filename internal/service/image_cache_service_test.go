@@ -0,0 +1,76 @@
+package service
+
+import (
+	"image"
+	"image/color"
+	"math/bits"
+	"testing"
+)
+
+// gradient draws a horizontal ramp, dark to light or light to dark.
+func gradient(w, h int, darkFirst bool) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := uint8(x * 255 / (w - 1))
+			if !darkFirst {
+				v = 255 - v
+			}
+			img.Set(x, y, color.RGBA{v, v, v, 255})
+		}
+	}
+	return img
+}
+
+func filled(w, h int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestDifferenceHash(t *testing.T) {
+	tests := []struct {
+		name string
+		img  image.Image
+		want uint64
+	}{
+		{"empty image", image.NewRGBA(image.Rect(0, 0, 0, 0)), 0},
+		{"flat colour", filled(90, 40, color.RGBA{30, 60, 90, 255}), 0},
+		{"dark to light", gradient(90, 40, true), ^uint64(0)},
+		{"light to dark", gradient(90, 40, false), 0},
+		{"transparent is white", filled(90, 40, color.RGBA{}), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := differenceHash(tt.img); got != tt.want {
+				t.Errorf("differenceHash = %064b, want %064b", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDifferenceHashIgnoresScale(t *testing.T) {
+	small := gradient(90, 40, true)
+	large := gradient(360, 160, true)
+	if d := bits.OnesCount64(differenceHash(small) ^ differenceHash(large)); d != 0 {
+		t.Errorf("hashes of the same image at two sizes differ in %d bits", d)
+	}
+
+	// A logo on a transparent background hashes like the same logo on white
+	onWhite := filled(90, 40, color.White)
+	onClear := filled(90, 40, color.RGBA{})
+	for x := 30; x < 60; x++ {
+		for y := 10; y < 30; y++ {
+			onWhite.Set(x, y, color.Black)
+			onClear.Set(x, y, color.Black)
+		}
+	}
+	if differenceHash(onWhite) != differenceHash(onClear) {
+		t.Error("transparent background hashed differently from white")
+	}
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/playwright-community/playwright-go"
+
+	"github.com/chandhuDev/JobLoop/internal/interfaces"
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	"github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/repository"
+)
+
+/* ================= CONFIG ================= */
+
+var (
+	llmExtractionCalls atomic.Int64
+
+	llmDigestMaxText  = 15000
+	llmDigestMaxLinks = 400
+)
+
+type llmJobEntry struct {
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Location string `json:"location"`
+	Team     string `json:"team"`
+}
+
+// llmExtractionBudget is the number of LLM extraction calls one run may make.
+// LLM_JOB_EXTRACTION_BUDGET=0 disables the fallback.
+func llmExtractionBudget() int64 {
+	budget, err := strconv.ParseInt(os.Getenv("LLM_JOB_EXTRACTION_BUDGET"), 10, 64)
+	if err != nil || budget < 0 {
+		return 25
+	}
+	return budget
+}
+
+/* ================= MAIN ================= */
+
+// onlyNoise reports whether none of the scanned links would survive the
+// repository's noise filter, i.e. the heuristics found nothing usable.
+func onlyNoise(jobs []models.LinkData) bool {
+	for _, job := range jobs {
+		if !repository.IsNoise(job.Text, job.URL) {
+			return false
+		}
+	}
+	return true
+}
+
+// extractJobsWithLLM sends a text and link digest of the page to Claude and
+// keeps only the entries whose URL is one of the page's real anchors.
+func extractJobsWithLLM(ctx context.Context, client *anthropic.Client, usage interfaces.UsageRecorder, page playwright.Page, companyName string) []models.LinkData {
+	if client == nil || page == nil {
+		return nil
+	}
+
+	if !usageAllowed(usage, models.UsageStageJobExtraction) {
+		return nil
+	}
+
+	text, links := pageDigest(page)
+	if len(links) == 0 {
+		logger.Info().Str("company", companyName).Msg("No links on page, skipping LLM job extraction")
+		return nil
+	}
+
+	// Only calls that are actually sent count against the run budget
+	if llmExtractionCalls.Add(1) > llmExtractionBudget() {
+		logger.Warn().Str("company", companyName).Msg("LLM job extraction budget exhausted, skipping fallback")
+		return nil
+	}
+
+	var digest strings.Builder
+	for i, link := range links {
+		fmt.Fprintf(&digest, "[%d] %s -> %s\n", i+1, link.Text, link.URL)
+	}
+
+	resp, err := client.Messages.New(ctx, anthropic.MessageNewParams{
+		Model:     anthropic.Model(envString("JOB_EXTRACTION_MODEL", string(anthropic.ModelClaudeSonnet4_5_20250929))),
+		MaxTokens: 4096,
+		Messages: []anthropic.MessageParam{
+			{
+				Role: anthropic.MessageParamRoleUser,
+				Content: []anthropic.ContentBlockParamUnion{
+					{
+						OfText: &anthropic.TextBlockParam{
+							Type: "text",
+							Text: fmt.Sprintf(`This is the careers page of "%s" (%s).
+
+PAGE TEXT:
+%s
+
+LINKS:
+%s
+List every open job posting on this page. Use only URLs copied exactly from LINKS; if a job has no link of its own, use the link that opens it or its application.
+Return ONLY a JSON array, no prose: [{"title": "", "url": "", "location": "", "team": ""}]
+Return [] if the page lists no jobs.`, companyName, page.URL(), text, digest.String()),
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		logger.Error().Err(err).Str("company", companyName).Msg("LLM job extraction failed")
+		return nil
+	}
+
+	recordUsage(usage, models.LLMUsage{
+		Stage:       models.UsageStageJobExtraction,
+		CompanyName: companyName,
+		Model:       string(resp.Model),
+		Usage:       messageUsage(resp.Usage),
+	})
+
+	var reply string
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			reply += block.Text
+		}
+	}
+
+	entries, err := parseLLMJobEntries(reply)
+	if err != nil {
+		logger.Warn().Err(err).Str("company", companyName).Msg("Failed to parse LLM job extraction reply")
+		return nil
+	}
+
+	jobs := validateLLMJobEntries(entries, links, page.URL())
+
+	logger.Info().
+		Str("company", companyName).
+		Int("returned", len(entries)).
+		Int("accepted", len(jobs)).
+		Int("input_tokens", int(resp.Usage.InputTokens)).
+		Int("output_tokens", int(resp.Usage.OutputTokens)).
+		Msg("LLM job extraction completed")
+
+	return jobs
+}
+
+/* ================= DIGEST ================= */
+
+// pageDigest returns the visible text of the page and every anchor across its
+// frames, with whitespace collapsed and both capped to keep the prompt small.
+func pageDigest(page playwright.Page) (string, []models.LinkData) {
+	var text strings.Builder
+	var links []models.LinkData
+	seen := make(map[string]bool)
+
+	for _, frame := range page.Frames() {
+		if res, err := frame.Evaluate(`() => document.body ? document.body.innerText : ""`); err == nil {
+			body := strings.Join(strings.Fields(toString(res)), " ")
+			if remaining := llmDigestMaxText - text.Len(); remaining > 0 {
+				if len(body) > remaining {
+					body = body[:remaining]
+				}
+				text.WriteString(body)
+				text.WriteString("\n")
+			}
+		}
+
+		frameBase, err := url.Parse(frame.URL())
+		if err != nil {
+			continue
+		}
+
+		for _, anchor := range collectFrameAnchors(frame) {
+			if len(links) >= llmDigestMaxLinks {
+				break
+			}
+
+			href := anchor.Resolved
+			if href == "" {
+				href = resolveURL(anchor.Href, frameBase)
+			}
+			if !strings.HasPrefix(href, "http") {
+				continue
+			}
+
+			key := normalizeAnchorURL(href)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			label := strings.Join(strings.Fields(anchor.Text), " ")
+			if label == "" {
+				label = strings.Join(strings.Fields(anchor.ContainerText), " ")
+			}
+			if runes := []rune(label); len(runes) > 150 {
+				label = string(runes[:150])
+			}
+
+			links = append(links, models.LinkData{URL: href, Text: label})
+		}
+	}
+
+	return strings.ToValidUTF8(text.String(), ""), links
+}
+
+/* ================= VALIDATION ================= */
+
+func parseLLMJobEntries(reply string) ([]llmJobEntry, error) {
+	start := strings.Index(reply, "[")
+	end := strings.LastIndex(reply, "]")
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("no JSON array in reply")
+	}
+
+	var entries []llmJobEntry
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// validateLLMJobEntries drops entries whose URL is not an anchor on the page,
+// so a hallucinated link can never reach the jobs table.
+func validateLLMJobEntries(entries []llmJobEntry, links []models.LinkData, pageURL string) []models.LinkData {
+	anchors := make(map[string]string, len(links))
+	for _, link := range links {
+		anchors[normalizeAnchorURL(link.URL)] = link.URL
+	}
+
+	base, _ := url.Parse(pageURL)
+	seenTitles := make(map[string]bool)
+
+	var jobs []models.LinkData
+	for _, entry := range entries {
+		title := strings.TrimSpace(entry.Title)
+		if title == "" || seenTitles[strings.ToLower(title)] {
+			continue
+		}
+
+		href := strings.TrimSpace(entry.URL)
+		if base != nil {
+			href = resolveURL(href, base)
+		}
+
+		real, ok := anchors[normalizeAnchorURL(href)]
+		if !ok {
+			logger.Debug().Str("title", title).Str("url", entry.URL).Msg("Dropping LLM job with unknown URL")
+			continue
+		}
+
+		seenTitles[strings.ToLower(title)] = true
+		jobs = append(jobs, models.LinkData{
+			URL:      real,
+			Text:     title,
+			Location: strings.TrimSpace(entry.Location),
+			Team:     strings.TrimSpace(entry.Team),
+			Source:   models.JobSourceLLM,
+		})
+	}
+
+	return jobs
+}
+
+// normalizeAnchorURL keeps the query, which often carries the job id
+// (?gh_jid=123), and drops only the fragment and trailing slash.
+func normalizeAnchorURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	parsed.Fragment = ""
+	parsed.Host = strings.ToLower(parsed.Host)
+	return strings.TrimSuffix(parsed.String(), "/")
+}
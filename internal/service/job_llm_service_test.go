@@ -0,0 +1,120 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chandhuDev/JobLoop/internal/models"
+)
+
+func TestParseLLMJobEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		reply   string
+		want    []llmJobEntry
+		wantErr bool
+	}{
+		{
+			name:  "bare array",
+			reply: `[{"title":"Backend Engineer","url":"/jobs/1","location":"Remote"}]`,
+			want:  []llmJobEntry{{Title: "Backend Engineer", URL: "/jobs/1", Location: "Remote"}},
+		},
+		{
+			name:  "array inside prose and a code fence",
+			reply: "Here are the jobs:\n```json\n[{\"title\":\"Designer\",\"url\":\"https://acme.com/jobs/2\"}]\n```",
+			want:  []llmJobEntry{{Title: "Designer", URL: "https://acme.com/jobs/2"}},
+		},
+		{
+			name:  "empty array",
+			reply: "[]",
+			want:  []llmJobEntry{},
+		},
+		{
+			name:    "no array",
+			reply:   "There are no open positions on this page.",
+			wantErr: true,
+		},
+		{
+			name:    "malformed JSON",
+			reply:   `[{"title": "Engineer",]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLLMJobEntries(tt.reply)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLLMJobEntries error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLLMJobEntries = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateLLMJobEntries(t *testing.T) {
+	pageURL := "https://acme.com/careers"
+	links := []models.LinkData{
+		{URL: "https://acme.com/careers/backend-engineer", Text: "Backend Engineer"},
+		{URL: "https://boards.greenhouse.io/acme/jobs/123?gh_jid=123", Text: "Designer"},
+		{URL: "https://acme.com/careers/sales/", Text: "Account Executive"},
+	}
+
+	tests := []struct {
+		name    string
+		entries []llmJobEntry
+		want    []models.LinkData
+	}{
+		{
+			name:    "relative URL resolved against the page",
+			entries: []llmJobEntry{{Title: " Backend Engineer ", URL: "/careers/backend-engineer", Location: " Berlin "}},
+			want: []models.LinkData{{
+				URL: "https://acme.com/careers/backend-engineer", Text: "Backend Engineer", Location: "Berlin", Source: models.JobSourceLLM,
+			}},
+		},
+		{
+			name:    "query kept, fragment and trailing slash ignored",
+			entries: []llmJobEntry{{Title: "Designer", URL: "https://boards.greenhouse.io/acme/jobs/123?gh_jid=123#apply"}},
+			want: []models.LinkData{{
+				URL: "https://boards.greenhouse.io/acme/jobs/123?gh_jid=123", Text: "Designer", Source: models.JobSourceLLM,
+			}},
+		},
+		{
+			name:    "page anchor URL returned as written on the page",
+			entries: []llmJobEntry{{Title: "Account Executive", URL: "https://ACME.com/careers/sales"}},
+			want: []models.LinkData{{
+				URL: "https://acme.com/careers/sales/", Text: "Account Executive", Source: models.JobSourceLLM,
+			}},
+		},
+		{
+			name:    "hallucinated URL dropped",
+			entries: []llmJobEntry{{Title: "Staff Engineer", URL: "https://acme.com/careers/staff-engineer"}},
+		},
+		{
+			name:    "different job id dropped",
+			entries: []llmJobEntry{{Title: "Designer", URL: "https://boards.greenhouse.io/acme/jobs/123?gh_jid=999"}},
+		},
+		{
+			name: "empty and duplicate titles dropped",
+			entries: []llmJobEntry{
+				{Title: "", URL: "/careers/backend-engineer"},
+				{Title: "Backend Engineer", URL: "/careers/backend-engineer"},
+				{Title: "backend engineer", URL: "/careers/backend-engineer"},
+			},
+			want: []models.LinkData{{
+				URL: "https://acme.com/careers/backend-engineer", Text: "Backend Engineer", Source: models.JobSourceLLM,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateLLMJobEntries(tt.entries, links, pageURL)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateLLMJobEntries = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
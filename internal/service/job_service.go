@@ -1,18 +1,25 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/playwright-community/playwright-go"
+	"golang.org/x/net/publicsuffix"
 
 	"github.com/chandhuDev/JobLoop/internal/interfaces"
 	"github.com/chandhuDev/JobLoop/internal/logger"
 	"github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/repository"
 )
 
 /* ================= CONFIG ================= */
@@ -32,17 +39,90 @@ var (
 		"operations", "acquisition", "business", "analyst", "customer",
 	}
 
+	loadMoreKeywords = []string{
+		"load more", "show more", "see more", "view more", "more jobs", "more positions",
+		"more openings", "more roles", "show all jobs", "load all",
+	}
+
+	careersSubdomains = []string{"careers", "jobs", "work"}
+
+	// Words in jobKeywords that also fill marketing navigation ("Platform",
+	// "Solutions", "Customers"), so the crawl does not count them as titles
+	crawlGenericTitleWords = map[string]bool{
+		"senior": true, "lead": true, "experience": true, "sales": true, "platform": true, "head": true,
+		"product": true, "principal": true, "strategy": true, "solutions": true, "partner": true,
+		"employee": true, "finance": true, "account": true, "executive": true, "sr.": true,
+		"strategic": true, "operations": true, "acquisition": true, "business": true, "customer": true,
+	}
+
+	// Role nouns that only appear in job titles
+	crawlRoleKeywords = []string{"developer", "designer", "scientist", "architect", "recruiter", "intern"}
+
+	// Job posting and application URLs: /jobs/123, /careers/backend-engineer,
+	// ?gh_jid=123, /apply
+	crawlApplyURLPattern = `/(jobs?|careers?|positions?|openings?|vacanc(y|ies)|roles?)/[^/?#]{3,}|[?&]gh_jid=|/apply(/|$|\?)`
+
+	// Job-title anchors under one path that make a bare subdomain a job list
+	subdomainMinJobAnchors = 3
+
+	crawlMaxDepth        = 2
+	crawlMaxPages        = 15
+	crawlMaxLinksPerPage = 30
+	crawlMinScore        = 8
+	crawlTimeBudget      = 2 * time.Minute
+
+	hostedBoardURLs = []string{
+		"https://boards.greenhouse.io/%s",
+		"https://jobs.lever.co/%s",
+		"https://jobs.ashbyhq.com/%s",
+		"https://apply.workable.com/%s",
+	}
+
+	companySuffixes = map[string]bool{
+		"inc": true, "llc": true, "ltd": true, "limited": true, "gmbh": true, "corp": true,
+		"corporation": true, "co": true, "plc": true, "sa": true, "ag": true, "bv": true, "hq": true,
+	}
+
+	searchButtonKeywords = []string{
+		"search", "find jobs", "find a job", "show results", "show jobs", "view jobs", "see jobs",
+	}
+
+	// Blank first; some boards reject an empty query and need a wildcard
+	searchFormQueries = []string{"", "*"}
+
+	atsHosts = []string{
+		"greenhouse.io", "lever.co", "workday.com", "myworkdayjobs.com", "ashbyhq.com",
+		"workable.com", "smartrecruiters.com", "recruitee.com", "bamboohr.com", "jobvite.com",
+		"icims.com", "teamtailor.com", "personio.", "breezy.hr", "jazzhr.com", "pinpointhq.com",
+	}
+
 	jobWaitTimeout = 3 * time.Second
+
+	maxLoadMoreClicks = 20
+	maxScrollRounds   = 15
+	expandSettleWait  = 1500 * time.Millisecond
+	expandTimeBudget  = 60 * time.Second
+	clickPageTimeout  = 10 * time.Second
+
+	// Screenshot, HTML and trace of a company that ran out of time are taken
+	// within this, after which the tab is closed regardless
+	deadlineCaptureTimeout = 15 * time.Second
 )
 
 /* ================= MAIN ================= */
 
-func ScrapeJobs(browser interfaces.BrowserClient, companyURL string) ([]models.LinkData, error) {
-	if browser == nil {
+// ScrapeJobs finds and scans the company's careers page within the company
+// deadline. The tab is closed as soon as ctx ends; when the deadline ends its
+// artifacts are captured first so slow companies still leave a record.
+func ScrapeJobs(ctx context.Context, scraper *interfaces.ScraperClient, company models.SeedCompanyResult) ([]models.LinkData, error) {
+	if scraper == nil || scraper.Browser == nil {
 		return nil, fmt.Errorf("browser is nil")
 	}
 
-	page, err := browser.RunInNewTab()
+	companyCtx, cancel := withCompanyDeadline(ctx)
+	defer cancel()
+
+	page, err := scraper.Browser.RunInSession(ctx, sessionName("jobs", company.CompanyURL))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new tab: %w", err)
 	}
@@ -51,6 +131,96 @@ func ScrapeJobs(browser interfaces.BrowserClient, companyURL string) ([]models.L
 	}
 	defer page.Close()
 
+	record := scraper.Artifacts != nil && scraper.Artifacts.EnabledFor(company.CompanyName, company.CompanyURL)
+	tracing := record && scraper.Artifacts.StartTrace(page, company.CompanyName)
+
+	// The deadline closes the tab, which also ends the scrape running on it
+	var deadlineArtifacts models.ArtifactPaths
+	closed := make(chan struct{})
+	stopDeadline := context.AfterFunc(companyCtx, func() {
+		defer close(closed)
+		defer page.Close()
+		if record && ctx.Err() == nil {
+			deadlineArtifacts = captureWithin(scraper.Artifacts, page, company.CompanyName, tracing)
+		}
+	})
+	defer stopDeadline()
+
+	jobs, err := scrapeJobsOnPage(page, company)
+
+	// A closed tab surfaces as a Playwright error; report why it was closed
+	if companyCtx.Err() != nil && (err != nil || len(jobs) == 0) {
+		err = fmt.Errorf("job scrape stopped: %w", companyCtx.Err())
+	}
+
+	// Heuristics reached a careers page but found nothing usable on it
+	if err == nil && onlyNoise(jobs) {
+		logger.Info().Str("company", company.CompanyName).Int("scanned", len(jobs)).Msg("No usable jobs found, trying LLM extraction")
+		if llmJobs := extractJobsWithLLM(companyCtx, scraper.Vision, scraper.Usage, page, company.CompanyName); len(llmJobs) > 0 {
+			logJobs(llmJobs)
+			jobs = llmJobs
+		}
+	}
+
+	deadlineHit := !stopDeadline()
+	if deadlineHit {
+		<-closed
+	}
+
+	if err == nil && len(jobs) > 0 {
+		if tracing && !deadlineHit {
+			page.Context().Tracing().Stop()
+		}
+		return jobs, nil
+	}
+
+	reason := "no jobs found"
+	if err != nil {
+		reason = err.Error()
+	}
+
+	// Nothing to record when the run itself is shutting down
+	if ctx.Err() != nil {
+		return jobs, err
+	}
+
+	artifacts := deadlineArtifacts
+	if record && !deadlineHit {
+		artifacts = scraper.Artifacts.Capture(page, company.CompanyName, "jobs", tracing)
+	}
+
+	if scraper.DbClient != nil && company.SeedCompanyId != 0 {
+		if ferr := repository.CreateScrapeFailure(scraper.DbClient.GetDB().WithContext(ctx), company.SeedCompanyId, scraper.RunID, "jobs", reason, artifacts); ferr != nil {
+			logger.Error().Err(ferr).Str("company", company.CompanyName).Msg("Failed to record scrape failure")
+		}
+	}
+
+	return jobs, err
+}
+
+// captureWithin saves the failure artifacts of a tab that is about to be
+// closed, giving up after deadlineCaptureTimeout so a hung page cannot hold
+// the worker.
+func captureWithin(artifacts interfaces.ArtifactClient, page playwright.Page, companyName string, withTrace bool) models.ArtifactPaths {
+	captureCtx, cancel := context.WithTimeout(context.Background(), deadlineCaptureTimeout)
+	defer cancel()
+
+	done := make(chan models.ArtifactPaths, 1)
+	go func() {
+		done <- artifacts.Capture(page, companyName, "jobs", withTrace)
+	}()
+
+	select {
+	case paths := <-done:
+		return paths
+	case <-captureCtx.Done():
+		logger.Warn().Str("company", companyName).Msg("Timed out capturing artifacts at the company deadline")
+		return models.ArtifactPaths{}
+	}
+}
+
+func scrapeJobsOnPage(page playwright.Page, company models.SeedCompanyResult) ([]models.LinkData, error) {
+	companyURL := company.CompanyURL
 	baseURL, err := url.Parse(companyURL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid company URL: %w", err)
@@ -73,6 +243,7 @@ func ScrapeJobs(browser interfaces.BrowserClient, companyURL string) ([]models.L
 	page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
 		State: playwright.LoadStateNetworkidle,
 	})
+	dismissConsentBanners(page)
 
 	/* ---------- FIND CAREERS PAGE ---------- */
 
@@ -84,6 +255,18 @@ func ScrapeJobs(browser interfaces.BrowserClient, companyURL string) ([]models.L
 		careersURL = tryCommonCareerPaths(page, baseURL)
 	}
 
+	// Fallback: careers subdomains and hosted ATS boards
+	if careersURL == "" {
+		logger.Info().Msg("No common career path found, probing subdomains and hosted boards")
+		careersURL = probeCareersHosts(page, baseURL, company.CompanyName)
+	}
+
+	// Last resort: crawl the site and pick the page that looks most like a job list
+	if careersURL == "" {
+		logger.Info().Msg("No careers host found, crawling site for job listings")
+		careersURL = crawlForCareersPage(page, baseURL)
+	}
+
 	if careersURL == "" {
 		return nil, fmt.Errorf("no careers/jobs page found")
 	}
@@ -107,6 +290,7 @@ func ScrapeJobs(browser interfaces.BrowserClient, companyURL string) ([]models.L
 	page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
 		State: playwright.LoadStateNetworkidle,
 	})
+	dismissConsentBanners(page)
 
 	/* ---------- FIND CTA LINKS FIRST ---------- */
 
@@ -178,6 +362,7 @@ func ScrapeJobs(browser interfaces.BrowserClient, companyURL string) ([]models.L
 			jobBaseURL = currentBaseURL
 		}
 
+		dismissConsentBanners(page)
 		waitForJobContent(page)
 
 		jobs := scanForJobsWithPagination(page, jobBaseURL, 10)
@@ -205,21 +390,172 @@ func ScrapeJobs(browser interfaces.BrowserClient, companyURL string) ([]models.L
 		}
 	}
 
+	/* ---------- FALLBACK: SEARCH FORMS ---------- */
+
+	if jobs := scanBehindSearchForm(page, currentURL); len(jobs) > 0 {
+		logger.Info().Msg("Jobs found behind search form")
+		logJobs(jobs)
+		return jobs, nil
+	}
+
 	logger.Info().Msg("No jobs found")
 	return nil, nil
 }
 
-func tryCommonCareerPaths(page playwright.Page, baseURL *url.URL) string {
-	commonPaths := []string{
-		"/careers",
-		"/jobs",
-		"/careers/",
-		"/jobs/",
-		"/en/careers",
-		"/about/careers",
-		"/company/careers",
+/* ================= SEARCH FORMS ================= */
+
+// scanBehindSearchForm handles careers pages that list nothing until a search
+// is submitted. It tries the page the scraper ended on first, then the careers
+// page itself, submitting a blank query and then a wildcard.
+func scanBehindSearchForm(page playwright.Page, careersURL string) []models.LinkData {
+	candidates := []string{page.URL()}
+	if careersURL != "" && careersURL != page.URL() {
+		candidates = append(candidates, careersURL)
+	}
+
+	for i, candidate := range candidates {
+		if i > 0 {
+			if _, err := page.Goto(candidate, playwright.PageGotoOptions{
+				Timeout: playwright.Float(30000),
+			}); err != nil {
+				logger.Warn().Str("url", candidate).Err(err).Msg("Failed to return to careers page for search form")
+				continue
+			}
+			page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+				State: playwright.LoadStateNetworkidle,
+			})
+			dismissConsentBanners(page)
+		}
+
+		for _, query := range searchFormQueries {
+			if !submitJobSearchForm(page, query) {
+				break
+			}
+
+			waitForJobContent(page)
+
+			base, err := url.Parse(page.URL())
+			if err != nil {
+				continue
+			}
+
+			jobs := dedupeJobs(scanForJobsWithPagination(page, base, 10))
+			if len(jobs) > 0 {
+				return jobs
+			}
+
+			logger.Info().Str("query", query).Msg("Search form returned no jobs")
+		}
+	}
+
+	return nil
+}
+
+// submitJobSearchForm finds a job search form (a <form> or a loose search box
+// with a nearby button), picks "All ..." in any location/department selects,
+// fills the keyword box with the given query and submits. It reports whether
+// a form was found and submitted.
+func submitJobSearchForm(page playwright.Page, query string) bool {
+	js := `
+	() => {
+		const INPUT_HINTS = /keyword|search|query|job|title|position|role|what|^q$|suche|recherche|buscar|busca|検索/i;
+		const BUTTON_HINTS = %s;
+		const ALL_OPTION = /^(all|any|todos|todas|tous|toutes|alle|tutti|すべて)\b|all (locations|departments|teams|countries|offices|categories)/i;
+
+		document.querySelectorAll('[data-jobloop-search-input], [data-jobloop-search-submit]').forEach(el => {
+			el.removeAttribute('data-jobloop-search-input');
+			el.removeAttribute('data-jobloop-search-submit');
+		});
+
+		const visible = el => {
+			const r = el.getBoundingClientRect();
+			const s = window.getComputedStyle(el);
+			return r.width > 0 && r.height > 0 && s.visibility !== 'hidden' && s.display !== 'none';
+		};
+
+		const describe = el => [el.name, el.id, el.placeholder, el.getAttribute('aria-label'), el.type]
+			.filter(Boolean).join(' ');
+
+		const inputs = Array.from(document.querySelectorAll('input[type="search"], input[type="text"], input:not([type])'))
+			.filter(el => visible(el) && !el.closest('header, footer, nav') && INPUT_HINTS.test(describe(el)));
+
+		if (!inputs.length) return null;
+
+		const input = inputs[0];
+		const scope = input.closest('form') || input.closest('section, main, div') || document.body;
+
+		scope.querySelectorAll('select').forEach(sel => {
+			const opt = Array.from(sel.options).find(o => ALL_OPTION.test(o.text.trim()));
+			if (opt && sel.value !== opt.value) {
+				sel.value = opt.value;
+				sel.dispatchEvent(new Event('change', { bubbles: true }));
+			}
+		});
+
+		let button = null;
+		for (const el of scope.querySelectorAll('button, input[type="submit"], [role="button"], a')) {
+			const t = (el.innerText || el.value || el.getAttribute('aria-label') || '').trim().toLowerCase();
+			if (el.type === 'submit' || (t && BUTTON_HINTS.some(k => t.includes(k)))) {
+				if (visible(el)) { button = el; break; }
+			}
+		}
+
+		input.setAttribute('data-jobloop-search-input', '1');
+		if (button) button.setAttribute('data-jobloop-search-submit', '1');
+
+		return JSON.stringify({ form: !!input.closest('form'), button: !!button, input: describe(input) });
+	}
+	`
+
+	res, err := page.Evaluate(fmt.Sprintf(js, toJSArray(searchButtonKeywords)))
+	if err != nil || res == nil {
+		return false
 	}
 
+	var found struct {
+		Form   bool   `json:"form"`
+		Button bool   `json:"button"`
+		Input  string `json:"input"`
+	}
+	if err := json.Unmarshal([]byte(toString(res)), &found); err != nil {
+		return false
+	}
+
+	logger.Info().Str("input", found.Input).Bool("form", found.Form).Bool("button", found.Button).Str("query", query).Msg("Submitting job search form")
+
+	input := page.Locator("[data-jobloop-search-input]").First()
+	if err := input.Fill(query, playwright.LocatorFillOptions{
+		Timeout: playwright.Float(5000),
+	}); err != nil {
+		logger.Warn().Err(err).Msg("Failed to fill search input")
+		return false
+	}
+
+	if found.Button {
+		err = page.Locator("[data-jobloop-search-submit]").First().Click(playwright.LocatorClickOptions{
+			Timeout: playwright.Float(5000),
+		})
+	} else {
+		err = input.Press("Enter")
+	}
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to submit search form")
+		return false
+	}
+
+	page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+		State:   playwright.LoadStateNetworkidle,
+		Timeout: playwright.Float(10000),
+	})
+	dismissConsentBanners(page)
+
+	return true
+}
+
+func tryCommonCareerPaths(page playwright.Page, baseURL *url.URL) string {
+	// Localized paths come first when the homepage declares a language
+	commonPaths := pageKeywords(page).Paths
+
 	for _, path := range commonPaths {
 		testURL := baseURL.Scheme + "://" + baseURL.Host + path
 		logger.Info().Str("url", testURL).Msg("Trying career path")
@@ -240,6 +576,432 @@ func tryCommonCareerPaths(page playwright.Page, baseURL *url.URL) string {
 	return ""
 }
 
+/* ================= SUBDOMAINS / HOSTED BOARDS ================= */
+
+type careersCandidate struct {
+	URL    string
+	Slug   string // set for hosted boards; the final URL must keep it
+	Hosted bool
+}
+
+// probeCareersHosts tries careers.<domain>-style subdomains and the hosted
+// board URLs the big ATS vendors derive from a company slug. A candidate is
+// only accepted once the page it lands on is shown to belong to this company.
+func probeCareersHosts(page playwright.Page, baseURL *url.URL, companyName string) string {
+	domain := registrableDomain(baseURL.Hostname())
+	if domain == "" {
+		return ""
+	}
+
+	var candidates []careersCandidate
+	for _, sub := range careersSubdomains {
+		candidates = append(candidates, careersCandidate{URL: "https://" + sub + "." + domain})
+	}
+	for _, slug := range companySlugs(companyName, domain) {
+		for _, board := range hostedBoardURLs {
+			candidates = append(candidates, careersCandidate{
+				URL:    fmt.Sprintf(board, slug),
+				Slug:   slug,
+				Hosted: true,
+			})
+		}
+	}
+
+	for _, candidate := range candidates {
+		logger.Info().Str("url", candidate.URL).Msg("Probing careers host")
+
+		resp, err := page.Goto(candidate.URL, playwright.PageGotoOptions{
+			Timeout: playwright.Float(10000),
+		})
+		if err != nil || resp == nil || resp.Status() >= 400 {
+			continue
+		}
+		page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+			State:   playwright.LoadStateNetworkidle,
+			Timeout: playwright.Float(10000),
+		})
+
+		if !careersCandidateBelongs(page, candidate, companyName, domain) {
+			logger.Info().Str("url", candidate.URL).Str("final_url", page.URL()).Msg("Careers host does not belong to company, skipping")
+			continue
+		}
+
+		logger.Info().Str("url", candidate.URL).Str("final_url", page.URL()).Msg("Found careers host")
+		return page.URL()
+	}
+
+	return ""
+}
+
+// careersCandidateBelongs guards against boards that exist under someone
+// else's slug and subdomains that just redirect to the homepage. A subdomain
+// must render a job list itself, redirect to an ATS board under one of the
+// company's slugs, or redirect to a careers path on the main site. A hosted
+// board must link to the company's domain or carry its name as whole words
+// in its title or og:site_name.
+func careersCandidateBelongs(page playwright.Page, candidate careersCandidate, companyName string, domain string) bool {
+	requested, err := url.Parse(candidate.URL)
+	if err != nil {
+		return false
+	}
+	final, err := url.Parse(page.URL())
+	if err != nil {
+		return false
+	}
+
+	if !candidate.Hosted {
+		switch {
+		case strings.EqualFold(final.Hostname(), requested.Hostname()):
+			// Subdomains also serve app logins and status pages
+			return hasJobListContent(page)
+		case isATSHost(final.Hostname()):
+			// careers.acme.com -> boards.greenhouse.io/acme
+			return atsBoardMatchesSlug(final, companySlugs(companyName, domain))
+		case registrableDomain(final.Hostname()) == domain:
+			// Redirecting back to the main site means the subdomain is not a careers site
+			return containsAny(strings.ToLower(final.Path), pageKeywords(page).Careers)
+		default:
+			return false
+		}
+	}
+
+	// Boards redirect unknown slugs to the vendor's marketing site or an error
+	// page; moving between the vendor's own board hosts is fine
+	if registrableDomain(final.Hostname()) != registrableDomain(requested.Hostname()) ||
+		strings.EqualFold(final.Hostname(), registrableDomain(final.Hostname())) ||
+		!strings.Contains(strings.ToLower(final.Path), candidate.Slug) ||
+		final.Query().Get("error") != "" {
+		return false
+	}
+
+	res, err := page.Evaluate(`
+	() => JSON.stringify({
+		title: document.title || "",
+		siteName: (document.querySelector('meta[property="og:site_name"]') || {}).content || "",
+		links: Array.from(document.querySelectorAll("a[href]")).map(a => a.href).slice(0, 500)
+	})
+	`)
+	if err != nil {
+		return false
+	}
+
+	var snapshot struct {
+		Title    string   `json:"title"`
+		SiteName string   `json:"siteName"`
+		Links    []string `json:"links"`
+	}
+	if err := json.Unmarshal([]byte(toString(res)), &snapshot); err != nil {
+		return false
+	}
+
+	for _, link := range snapshot.Links {
+		if parsed, err := url.Parse(link); err == nil && registrableDomain(parsed.Hostname()) == domain {
+			return true
+		}
+	}
+
+	// Without a link home, only the board's own name counts, as whole words:
+	// body text matches namesakes and runs words together
+	return containsNameWords(snapshot.Title, companyName) || containsNameWords(snapshot.SiteName, companyName)
+}
+
+// hasJobListContent reports whether the rendered page carries job postings:
+// JobPosting markup, an ATS embed or a run of job-title anchors.
+func hasJobListContent(page playwright.Page) bool {
+	signals := collectCrawlSignals(page, pageKeywords(page))
+	return signals.JobPosting || signals.ATS || signals.RepeatedGroup >= subdomainMinJobAnchors
+}
+
+func isATSHost(host string) bool {
+	host = strings.ToLower(host)
+	for _, ats := range atsHosts {
+		if strings.HasSuffix(ats, ".") {
+			if strings.Contains(host, ats) {
+				return true
+			}
+		} else if host == ats || strings.HasSuffix(host, "."+ats) {
+			return true
+		}
+	}
+	return false
+}
+
+// atsBoardMatchesSlug reports whether an ATS board URL names one of the
+// company's slugs as a host label (acme.bamboohr.com) or a path segment
+// (jobs.lever.co/acme).
+func atsBoardMatchesSlug(board *url.URL, slugs []string) bool {
+	parts := strings.Split(strings.ToLower(board.Hostname()), ".")
+	parts = append(parts, strings.Split(strings.ToLower(board.Path), "/")...)
+	for _, part := range parts {
+		for _, slug := range slugs {
+			if part != "" && part == slug {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// companySlugs returns the slugs ATS vendors are likely to use for a company:
+// the squashed and hyphenated forms of its name, and its domain label.
+func companySlugs(companyName string, domain string) []string {
+	var slugs []string
+
+	name := strings.ToLower(strings.TrimSpace(companyName))
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	if len(words) > 0 {
+		slugs = append(slugs, strings.Join(words, ""), strings.Join(words, "-"))
+	}
+
+	if idx := strings.Index(domain, "."); idx > 0 {
+		slugs = append(slugs, domain[:idx])
+	}
+
+	return mergeKeywords(slugs)
+}
+
+/* ================= SAME-SITE CRAWL ================= */
+
+type crawlSignals struct {
+	JobAnchors    int      `json:"jobAnchors"`
+	RepeatedGroup int      `json:"repeatedGroup"`
+	ATS           bool     `json:"ats"`
+	JobPosting    bool     `json:"jobPosting"`
+	Links         []string `json:"links"`
+	CareersLinks  []string `json:"careersLinks"`
+}
+
+// crawlForCareersPage runs a bounded breadth-first crawl over same-site links
+// from the homepage and returns the page with the strongest job-listing
+// signals, or "" when nothing scores above crawlMinScore. The homepage itself
+// is only a starting point, never the answer.
+func crawlForCareersPage(page playwright.Page, baseURL *url.URL) string {
+	domain := registrableDomain(baseURL.Hostname())
+	deadline := time.Now().Add(crawlTimeBudget)
+
+	type queued struct {
+		URL   string
+		Depth int
+	}
+
+	start := baseURL.String()
+	queue := []queued{{URL: start, Depth: 0}}
+	visited := map[string]bool{normalizeCrawlURL(start): true}
+
+	bestURL := ""
+	bestScore := 0
+	pages := 0
+
+	for len(queue) > 0 && pages < crawlMaxPages && time.Now().Before(deadline) {
+		current := queue[0]
+		queue = queue[1:]
+
+		resp, err := page.Goto(current.URL, playwright.PageGotoOptions{
+			Timeout: playwright.Float(15000),
+		})
+		if err != nil || (resp != nil && resp.Status() >= 400) {
+			continue
+		}
+		page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+			State:   playwright.LoadStateNetworkidle,
+			Timeout: playwright.Float(10000),
+		})
+		dismissConsentBanners(page)
+		pages++
+
+		keywords := pageKeywords(page)
+		signals := collectCrawlSignals(page, keywords)
+		score := 0
+		if current.Depth > 0 && normalizeCrawlURL(page.URL()) != normalizeCrawlURL(start) {
+			score = scoreCrawlSignals(signals, page.URL(), keywords)
+		}
+
+		logger.Info().
+			Str("url", page.URL()).
+			Int("depth", current.Depth).
+			Int("score", score).
+			Int("job_anchors", signals.JobAnchors).
+			Int("repeated_group", signals.RepeatedGroup).
+			Bool("ats", signals.ATS).
+			Bool("job_posting", signals.JobPosting).
+			Msg("Crawled page")
+
+		if score > bestScore {
+			bestScore = score
+			bestURL = page.URL()
+		}
+
+		if current.Depth >= crawlMaxDepth {
+			continue
+		}
+
+		// Careers-looking links jump the queue so small budgets still reach them
+		var next []queued
+	collect:
+		for _, links := range [][]string{signals.CareersLinks, signals.Links} {
+			for _, link := range links {
+				parsed, err := url.Parse(link)
+				if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+					continue
+				}
+				if registrableDomain(parsed.Hostname()) != domain || isStaticAsset(parsed.Path) {
+					continue
+				}
+				key := normalizeCrawlURL(link)
+				if visited[key] {
+					continue
+				}
+				visited[key] = true
+				next = append(next, queued{URL: link, Depth: current.Depth + 1})
+				if len(next) >= crawlMaxLinksPerPage {
+					break collect
+				}
+			}
+		}
+		queue = append(queue, next...)
+	}
+
+	if bestScore < crawlMinScore {
+		logger.Info().Int("pages", pages).Int("best_score", bestScore).Msg("Crawl found no job listing page")
+		return ""
+	}
+
+	logger.Info().Int("pages", pages).Int("score", bestScore).Str("url", bestURL).Msg("Crawl picked careers page")
+	return bestURL
+}
+
+func collectCrawlSignals(page playwright.Page, keywords LocaleKeywords) crawlSignals {
+	js := `
+	() => {
+		const TITLES = %s;
+		const CAREERS = %s;
+		const ATS = %s;
+		const APPLY = new RegExp(%s, "i");
+
+		const anchors = Array.from(document.querySelectorAll("a[href]"));
+		const links = [];
+		const careersLinks = [];
+		const groups = {};
+		let jobAnchors = 0;
+
+		for (const a of anchors) {
+			const href = a.href;
+			if (typeof href !== "string" || !href) continue;
+			const text = (a.innerText || "").trim().toLowerCase();
+
+			if (text.length >= 3 && text.length <= 200 && (TITLES.some(k => text.includes(k)) || APPLY.test(href))) {
+				jobAnchors++;
+				try {
+					const u = new URL(href);
+					const prefix = u.host + u.pathname.split("/").slice(0, -1).join("/");
+					groups[prefix] = (groups[prefix] || 0) + 1;
+				} catch {}
+			}
+
+			const lowerHref = href.toLowerCase();
+			if (CAREERS.some(k => text.includes(k) || lowerHref.includes(k))) careersLinks.push(href);
+			else links.push(href);
+		}
+
+		const fingerprints = Array.from(document.querySelectorAll("iframe[src], script[src], a[href]"))
+			.map(el => (el.src || el.href || "").toString().toLowerCase());
+		const ats = fingerprints.some(u => ATS.some(h => u.includes(h)));
+
+		const jobPosting =
+			Array.from(document.querySelectorAll('script[type="application/ld+json"]'))
+				.some(s => /"@type"\s*:\s*"JobPosting"/.test(s.textContent || "")) ||
+			!!document.querySelector('[itemtype*="schema.org/JobPosting"]');
+
+		return JSON.stringify({
+			jobAnchors,
+			repeatedGroup: Math.max(0, ...Object.values(groups)),
+			ats,
+			jobPosting,
+			links: links.slice(0, 200),
+			careersLinks: careersLinks.slice(0, 50)
+		});
+	}
+	`
+
+	res, err := page.Evaluate(fmt.Sprintf(js, toJSArray(crawlTitleKeywords(keywords)), toJSArray(keywords.Careers), toJSArray(atsHosts), toJSON(crawlApplyURLPattern)))
+	if err != nil {
+		return crawlSignals{}
+	}
+
+	var signals crawlSignals
+	if err := json.Unmarshal([]byte(toString(res)), &signals); err != nil {
+		return crawlSignals{}
+	}
+	return signals
+}
+
+// crawlTitleKeywords is the locale's job-title vocabulary without the words
+// that also name products and marketing pages.
+func crawlTitleKeywords(keywords LocaleKeywords) []string {
+	var titles []string
+	for _, k := range keywords.JobTitles {
+		if !crawlGenericTitleWords[k] {
+			titles = append(titles, k)
+		}
+	}
+	return mergeKeywords(titles, crawlRoleKeywords)
+}
+
+// scoreCrawlSignals weighs structured evidence (JobPosting markup, an ATS
+// embed) above counts of job-like anchors, and favours repeated anchors under
+// one path since that is what a listing looks like. A page needs at least one
+// strong signal, an ATS embed, JobPosting markup or a careers path, so a busy
+// navigation alone never qualifies. Careers keywords come from the page's
+// locale, including those added in the scraper config.
+func scoreCrawlSignals(signals crawlSignals, pageURL string, keywords LocaleKeywords) int {
+	careersPath := false
+	if parsed, err := url.Parse(pageURL); err == nil {
+		path := strings.ToLower(parsed.Path)
+		careersPath = containsAny(path, keywords.Careers)
+		for _, p := range keywords.Paths {
+			if strings.HasPrefix(path, strings.TrimSuffix(p, "/")) {
+				careersPath = true
+			}
+		}
+	}
+	if !signals.ATS && !signals.JobPosting && !careersPath {
+		return 0
+	}
+
+	score := signals.JobAnchors + 2*signals.RepeatedGroup
+	if signals.ATS {
+		score += 10
+	}
+	if signals.JobPosting {
+		score += 15
+	}
+	if careersPath {
+		score += 3
+	}
+	return score
+}
+
+func normalizeCrawlURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	parsed.Fragment = ""
+	parsed.RawQuery = ""
+	parsed.Host = strings.TrimPrefix(strings.ToLower(parsed.Host), "www.")
+	return strings.TrimSuffix(parsed.String(), "/")
+}
+
+func isStaticAsset(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".pdf", ".jpg", ".jpeg", ".png", ".gif", ".svg", ".webp", ".zip", ".mp4", ".mp3", ".css", ".js", ".xml", ".json", ".ico":
+		return true
+	}
+	return false
+}
+
 /* ================= FIND CAREERS ================= */
 
 func findCareersLink(page playwright.Page, base *url.URL) (string, error) {
@@ -256,7 +1018,7 @@ func findCareersLink(page playwright.Page, base *url.URL) (string, error) {
 	}
 	`
 
-	res, _ := page.Evaluate(fmt.Sprintf(js, toJSArray(careerKeywords)))
+	res, _ := page.Evaluate(fmt.Sprintf(js, toJSArray(pageKeywords(page).Careers)))
 	if res == nil {
 		return "", nil
 	}
@@ -314,7 +1076,7 @@ func findCTAs(page playwright.Page) []CTA {
 	}
 	`
 
-	res, _ := page.Evaluate(fmt.Sprintf(js, toJSArray(ctaKeywords)))
+	res, _ := page.Evaluate(fmt.Sprintf(js, toJSArray(pageKeywords(page).CTAs)))
 	var ctas []CTA
 
 	arr, ok := res.([]interface{})
@@ -337,86 +1099,171 @@ func findCTAs(page playwright.Page) []CTA {
 /* ================= JOB SCAN ================= */
 
 func scanForJobs(page playwright.Page, baseURL *url.URL) []models.LinkData {
-	logger.Info().Msg("ENTER scanForJobs (container-aware, text-only)")
+	logger.Info().Msg("ENTER scanForJobs (frame and shadow DOM aware, text-only)")
 
 	page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
 		State: playwright.LoadStateNetworkidle,
 	})
 
 	var jobs []models.LinkData
+	var atsJobs []models.LinkData
 	seen := make(map[string]bool)
 
-	// Try to find Greenhouse/Lever/ATS iframe
-	atsIframe := page.FrameLocator("iframe[src*='greenhouse.io'], iframe[src*='lever.co'], iframe[src*='workday.com'], iframe[src*='ashbyhq.com']")
+	mainFrame := page.MainFrame()
+	titleKeywords := pageKeywords(page).JobTitles
 
-	// Check if iframe exists by trying to get an anchor inside it
-	anchors := atsIframe.Locator("a[href]")
-	count, err := anchors.Count()
+	// Frames() is the whole frame tree, so iframes nested inside iframes and
+	// boards embedded from custom domains are scanned too
+	for _, frame := range page.Frames() {
+		isMain := frame == mainFrame
+		frameURL := frame.URL()
 
-	if err != nil || count == 0 {
-		logger.Info().Msg("No ATS iframe found, falling back to main page")
-		anchors = page.Locator("a[href]")
-		count, _ = anchors.Count()
-	} else {
-		logger.Info().Int("anchor_count", count).Msg("Found ATS iframe, scanning inside")
-	}
+		frameBase := baseURL
+		if !isMain {
+			if parsed, err := url.Parse(frameURL); err == nil && parsed.IsAbs() {
+				frameBase = parsed
+			}
+		}
+
+		anchors := collectFrameAnchors(frame)
+		if len(anchors) == 0 {
+			continue
+		}
+
+		isATS := !isMain && isATSFrameURL(frameURL)
+		if !isMain {
+			logger.Info().Str("frame_url", frameURL).Bool("ats", isATS).Int("anchor_count", len(anchors)).Msg("Scanning embedded frame")
+		}
+
+		for _, a := range anchors {
+			href := strings.TrimSpace(a.Href)
+			if href == "" || href == "#" {
+				continue
+			}
+
+			absoluteURL := a.Resolved
+			if absoluteURL == "" {
+				absoluteURL = resolveURL(href, frameBase)
+			}
+			if isPaginationOrFilterURL(absoluteURL) {
+				logger.Debug().Str("href", absoluteURL).Msg("Skipping pagination/filter URL")
+				continue
+			}
+			logger.Debug().Str("href", href).Str("absolute", absoluteURL).Msg("Processing anchor")
+
+			text := strings.TrimSpace(a.Text)
+			if len(text) < 3 {
+				text = strings.TrimSpace(a.ContainerText)
+			}
+
+			lowerText := strings.ToLower(text)
+
+			if !containsAny(lowerText, titleKeywords) {
+				continue
+			}
+
+			// NEW: Additional filter - if text is too long, it's likely a container/filter section
+			if len(text) > 500 {
+				logger.Debug().Str("href", absoluteURL).Int("text_length", len(text)).Msg("Skipping - text too long (likely container)")
+				continue
+			}
 
-	for i := 0; i < count; i++ {
-		a := anchors.Nth(i)
+			if seen[absoluteURL] {
+				continue
+			}
+			seen[absoluteURL] = true
 
-		href, err := a.GetAttribute("href")
-		if err != nil {
-			continue
-		}
-		href = strings.TrimSpace(href)
-		if href == "" || href == "#" {
-			continue
-		}
-		absoluteURL := resolveURL(href, baseURL)
-		if isPaginationOrFilterURL(absoluteURL) {
-			logger.Debug().Str("href", absoluteURL).Msg("Skipping pagination/filter URL")
-			continue
-		}
-		logger.Debug().Str("href", href).Str("absolute", absoluteURL).Msg("Processing anchor")
+			job := models.LinkData{
+				Text: text,
+				URL:  absoluteURL,
+			}
+			if !isMain {
+				job.FrameURL = frameURL
+			}
 
-		text, _ := a.TextContent()
-		text = strings.TrimSpace(text)
+			logger.Info().Str("href", absoluteURL).Str("text", text).Str("frame_url", job.FrameURL).Bool("shadow", a.InShadow).Msg("Job found")
 
-		if len(text) < 3 {
-			container := a.Locator("xpath=ancestor::tr[1] | ancestor::li[1] | ancestor::div[1]")
-			if cCount, _ := container.Count(); cCount > 0 {
-				ct, _ := container.First().TextContent()
-				text = strings.TrimSpace(ct)
+			if isATS {
+				atsJobs = append(atsJobs, job)
 			}
+			jobs = append(jobs, job)
 		}
+	}
 
-		lowerText := strings.ToLower(text)
+	// A known ATS embed is the listing itself; anchors elsewhere are page chrome
+	if len(atsJobs) > 0 {
+		logger.Info().Int("jobs", len(atsJobs)).Msg("Jobs found in ATS frame")
+		return atsJobs
+	}
 
-		if !containsAny(lowerText, jobKeywords) {
-			continue
-		}
+	logger.Info().Int("jobs", len(jobs)).Msg("Jobs found after scan")
+	return jobs
+}
 
-		// NEW: Additional filter - if text is too long, it's likely a container/filter section
-		if len(text) > 500 {
-			logger.Debug().Str("href", absoluteURL).Int("text_length", len(text)).Msg("Skipping - text too long (likely container)")
-			continue
-		}
+type frameAnchor struct {
+	Href          string `json:"href"`
+	Resolved      string `json:"resolved"`
+	Text          string `json:"text"`
+	ContainerText string `json:"containerText"`
+	InShadow      bool   `json:"inShadow"`
+}
 
-		if seen[absoluteURL] {
-			continue
-		}
-		seen[absoluteURL] = true
+// collectFrameAnchors returns every anchor in the frame's document, including
+// anchors inside open shadow roots of web components.
+func collectFrameAnchors(frame playwright.Frame) []frameAnchor {
+	res, err := frame.Evaluate(`
+	() => {
+		const out = [];
 
-		logger.Info().Str("href", absoluteURL).Str("text", text).Msg("Job found")
+		const containerText = a => {
+			let cur = a.parentElement || a.getRootNode().host;
+			while (cur) {
+				const tag = cur.tagName ? cur.tagName.toLowerCase() : '';
+				if (tag === 'tr' || tag === 'li' || tag === 'div') return cur.textContent || '';
+				cur = cur.parentElement || (cur.getRootNode && cur.getRootNode().host) || null;
+			}
+			return '';
+		};
+
+		const walk = (root, inShadow) => {
+			for (const a of root.querySelectorAll('a[href]')) {
+				out.push({
+					href: a.getAttribute('href') || '',
+					resolved: typeof a.href === 'string' ? a.href : '',
+					text: a.textContent || '',
+					containerText: (a.textContent || '').trim().length < 3 ? containerText(a) : '',
+					inShadow
+				});
+			}
+			for (const el of root.querySelectorAll('*')) {
+				if (el.shadowRoot) walk(el.shadowRoot, true);
+			}
+		};
 
-		jobs = append(jobs, models.LinkData{
-			Text: text,
-			URL:  absoluteURL,
-		})
+		walk(document, false);
+		return JSON.stringify(out);
+	}
+	`)
+	if err != nil {
+		logger.Debug().Err(err).Str("frame_url", frame.URL()).Msg("Failed to collect anchors from frame")
+		return nil
 	}
 
-	logger.Info().Int("jobs", len(jobs)).Msg("Jobs found after scan")
-	return jobs
+	var anchors []frameAnchor
+	if err := json.Unmarshal([]byte(toString(res)), &anchors); err != nil {
+		return nil
+	}
+	return anchors
+}
+
+func isATSFrameURL(frameURL string) bool {
+	lower := strings.ToLower(frameURL)
+	for _, host := range atsHosts {
+		if strings.Contains(lower, host) {
+			return true
+		}
+	}
+	return false
 }
 
 func isPaginationOrFilterURL(urlStr string) bool {
@@ -454,16 +1301,57 @@ type PaginationPattern struct {
 	StartIndex         int
 	IsZeroIndexed      bool
 	HasExplicitPageOne bool
-	Type               string
+	Type               string // "query", "path", "load_more", "infinite_scroll" or "click"
+	LoadMoreText       string // button label for "load_more"
+	NextSelector       string // control that advances a "click" board by one page
+	PageSelector       string // numbered control of a "click" board, {page} standing for the number
+}
+
+const (
+	paginationTypeQuery          = "query"
+	paginationTypePath           = "path"
+	paginationTypeLoadMore       = "load_more"
+	paginationTypeInfiniteScroll = "infinite_scroll"
+	paginationTypeClick          = "click"
+)
+
+// paginationCache remembers the pattern that worked for a careers page so
+// later scans of the same board skip discovery. Keys include the path because
+// hosted boards (boards.greenhouse.io/<slug>, jobs.lever.co/<slug>) serve many
+// companies from one host.
+var paginationCache sync.Map
+
+func paginationCacheKey(baseURL *url.URL) string {
+	return strings.ToLower(baseURL.Host) + strings.TrimSuffix(baseURL.EscapedPath(), "/")
+}
+
+// cachedPaginationPattern returns a copy of the cached pattern pointed at
+// baseURL, so page URLs are always built from the board being scanned.
+func cachedPaginationPattern(baseURL *url.URL) *PaginationPattern {
+	v, ok := paginationCache.Load(paginationCacheKey(baseURL))
+	if !ok {
+		return nil
+	}
+	pattern := *v.(*PaginationPattern)
+	pattern.BaseURL = baseURL.String()
+	return &pattern
+}
+
+func cachePaginationPattern(baseURL *url.URL, pattern *PaginationPattern) {
+	if pattern == nil {
+		return
+	}
+	paginationCache.Store(paginationCacheKey(baseURL), pattern)
 }
 
 // PaginationElement represents a clickable pagination element
 type PaginationElement struct {
-	AriaLabel  string
-	Href       string
-	Text       string
-	PageNumber int    // extracted from aria-label or text
-	Confidence string // "high", "medium", "low"
+	AriaLabel     string
+	Href          string
+	Text          string
+	PageNumber    int    // extracted from aria-label or text
+	Confidence    string // "high", "medium", "low"
+	RequiresClick bool   // button without any href, only reachable by clicking
 }
 
 func discoverPaginationPattern(page playwright.Page, baseURL *url.URL) (*PaginationPattern, error) {
@@ -523,9 +1411,15 @@ func discoverPaginationPattern(page playwright.Page, baseURL *url.URL) (*Paginat
 				href = strings.TrimSpace(href)
 				text = strings.TrimSpace(text)
 
-				// Skip if no href or data-href
+				// Buttons without any href can still drive SPA pagination
+				requiresClick := false
 				if href == "" {
-					continue
+					tagName, _ := elem.Evaluate("el => el.tagName.toLowerCase()", nil)
+					role, _ := elem.GetAttribute("role")
+					if toString(tagName) != "button" && role != "button" {
+						continue
+					}
+					requiresClick = true
 				}
 
 				// Skip if it's the current/active page
@@ -535,6 +1429,9 @@ func discoverPaginationPattern(page playwright.Page, baseURL *url.URL) (*Paginat
 					strings.Contains(strings.ToLower(class), "disabled") {
 					continue
 				}
+				if disabled, _ := elem.IsDisabled(); disabled {
+					continue
+				}
 
 				// Skip ellipsis
 				if text == "..." || text == "…" {
@@ -547,13 +1444,17 @@ func discoverPaginationPattern(page playwright.Page, baseURL *url.URL) (*Paginat
 				}
 
 				confidence := determineConfidence(ariaLabel, href, text, pageNum)
+				if requiresClick && confidence == "low" && (pageNum == 2 || isNext(ariaLabel, text)) {
+					confidence = "medium"
+				}
 
 				elements = append(elements, PaginationElement{
-					AriaLabel:  ariaLabel,
-					Href:       href,
-					Text:       text,
-					PageNumber: pageNum,
-					Confidence: confidence,
+					AriaLabel:     ariaLabel,
+					Href:          href,
+					Text:          text,
+					PageNumber:    pageNum,
+					Confidence:    confidence,
+					RequiresClick: requiresClick,
 				})
 
 				logger.Debug().
@@ -616,7 +1517,7 @@ func analyzeElementsWithClick(page playwright.Page, elements []PaginationElement
 	// If no page 2, try to find "next" button
 	if page2 == nil {
 		for i, elem := range reliable {
-			if isNextOrPrevious(elem.AriaLabel, elem.Text) && elem.Href != "" {
+			if isNextOrPrevious(elem.AriaLabel, elem.Text) && (elem.Href != "" || elem.RequiresClick) {
 				page2 = &reliable[i]
 				page2.PageNumber = 2 // Assume next goes to page 2
 				break
@@ -633,6 +1534,14 @@ func analyzeElementsWithClick(page playwright.Page, elements []PaginationElement
 		Str("aria-label", page2.AriaLabel).
 		Msg("Found page 2 element for pattern analysis")
 
+	nextSelector := ""
+	for _, elem := range elements {
+		if isNext(elem.AriaLabel, elem.Text) {
+			nextSelector = paginationElementSelector(elem)
+			break
+		}
+	}
+
 	// Check if href is just a number (data-href case) or looks incomplete
 	isJustNumber := regexp.MustCompile(`^\d+$`).MatchString(page2.Href)
 
@@ -651,12 +1560,7 @@ func analyzeElementsWithClick(page playwright.Page, elements []PaginationElement
 		logger.Info().Str("current_url", currentURL).Msg("Current URL before clicking")
 
 		// Find the clickable element on the page using aria-label
-		var clickableSelector string
-		if page2.AriaLabel != "" {
-			clickableSelector = fmt.Sprintf("a[aria-label='%s'], button[aria-label='%s']", page2.AriaLabel, page2.AriaLabel)
-		} else if page2.Text != "" {
-			clickableSelector = fmt.Sprintf("a:has-text('%s'), button:has-text('%s')", page2.Text, page2.Text)
-		}
+		clickableSelector := paginationElementSelector(*page2)
 
 		if clickableSelector == "" {
 			return nil, fmt.Errorf("cannot construct selector to click page 2 element")
@@ -673,6 +1577,8 @@ func analyzeElementsWithClick(page playwright.Page, elements []PaginationElement
 
 		logger.Info().Str("selector", clickableSelector).Msg("Clicking pagination element")
 
+		signatureBefore := jobListSignature(page)
+
 		// Click and wait for navigation
 		err = clickableElem.Click()
 		if err != nil {
@@ -695,12 +1601,37 @@ func analyzeElementsWithClick(page playwright.Page, elements []PaginationElement
 		newURL := page.URL()
 		logger.Info().Str("new_url", newURL).Msg("URL after clicking page 2")
 
-		// Navigate back to page 1
-		_, err = page.Goto(currentURL, playwright.PageGotoOptions{
-			WaitUntil: playwright.WaitUntilStateNetworkidle,
-		})
-		if err != nil {
-			logger.Warn().Err(err).Msg("Failed to navigate back to page 1")
+		// SPA boards swap the list without touching the URL
+		if newURL == currentURL && jobListSignature(page) != signatureBefore {
+			// Without a next control each page is reached through its own
+			// number; a control that cannot be numbered only yields page 2
+			pageSelector := ""
+			if nextSelector == "" {
+				pageSelector = numberedPageSelector(*page2)
+				if pageSelector == "" {
+					pageSelector = clickableSelector
+				}
+			}
+			logger.Info().Str("next_selector", nextSelector).Str("page_selector", pageSelector).Msg("Listing changed without URL change, using click pagination")
+
+			// The board stays on page 2: reloading would drop whatever was
+			// reached by clicking, such as a submitted search form
+			return &PaginationPattern{
+				BaseURL:      baseURL.String(),
+				Type:         paginationTypeClick,
+				NextSelector: nextSelector,
+				PageSelector: pageSelector,
+			}, nil
+		}
+
+		// Navigate back to page 1 only when the click actually navigated
+		if newURL != currentURL {
+			_, err = page.Goto(currentURL, playwright.PageGotoOptions{
+				WaitUntil: playwright.WaitUntilStateNetworkidle,
+			})
+			if err != nil {
+				logger.Warn().Err(err).Msg("Failed to navigate back to page 1")
+			}
 		}
 
 		// Now analyze the actual navigated URL
@@ -994,6 +1925,74 @@ func isNextOrPrevious(ariaLabel, text string) bool {
 		text == "→" || text == "←" || text == "»" || text == "«"
 }
 
+// isNext checks if element moves forward (unlike isNextOrPrevious)
+func isNext(ariaLabel, text string) bool {
+	combined := strings.ToLower(ariaLabel + " " + text)
+	return strings.Contains(combined, "next") ||
+		text == "→" || text == "»" || text == "›"
+}
+
+// paginationElementSelector builds a Playwright selector that finds a
+// pagination element again by its aria-label or exact text
+func paginationElementSelector(elem PaginationElement) string {
+	if elem.AriaLabel != "" {
+		return fmt.Sprintf("a[aria-label=%q], button[aria-label=%q], [role='button'][aria-label=%q]", elem.AriaLabel, elem.AriaLabel, elem.AriaLabel)
+	}
+	if elem.Text != "" {
+		return fmt.Sprintf(":is(a, button, [role='button']):text-is(%q)", elem.Text)
+	}
+	return ""
+}
+
+// pageNumberPlaceholder stands for the page number in PageSelector
+const pageNumberPlaceholder = "{page}"
+
+var pageTwoRegex = regexp.MustCompile(`\b2\b`)
+
+// numberedPageSelector turns the page-2 control ("Page 2", a bare "2") into a
+// selector template for any page, or "" when the number is not in its label.
+func numberedPageSelector(elem PaginationElement) string {
+	if elem.AriaLabel != "" {
+		if len(pageTwoRegex.FindAllString(elem.AriaLabel, -1)) != 1 {
+			return ""
+		}
+		return paginationElementSelector(PaginationElement{AriaLabel: pageTwoRegex.ReplaceAllString(elem.AriaLabel, pageNumberPlaceholder)})
+	}
+	if strings.TrimSpace(elem.Text) == "2" {
+		return paginationElementSelector(PaginationElement{Text: pageNumberPlaceholder})
+	}
+	return ""
+}
+
+// clickSelector returns the control to click for pageNum, or "" when the
+// board cannot be walked that far.
+func (p *PaginationPattern) clickSelector(pageNum int) string {
+	if p.NextSelector != "" {
+		return p.NextSelector
+	}
+	if strings.Contains(p.PageSelector, pageNumberPlaceholder) {
+		return strings.ReplaceAll(p.PageSelector, pageNumberPlaceholder, strconv.Itoa(pageNum))
+	}
+	if pageNum == 2 {
+		return p.PageSelector
+	}
+	return ""
+}
+
+// jobListSignature fingerprints the anchors currently on the page so a click
+// that re-renders the list in place can be detected
+func jobListSignature(page playwright.Page) string {
+	res, err := page.Evaluate(`
+	() => Array.from(document.querySelectorAll("a[href]"))
+		.map(a => a.getAttribute("href") + "|" + (a.innerText || "").trim().slice(0, 80))
+		.join("\n")
+	`)
+	if err != nil {
+		return ""
+	}
+	return toString(res)
+}
+
 // determineConfidence calculates confidence level for pagination element
 func determineConfidence(ariaLabel, href, text string, pageNum int) string {
 	hasAriaLabel := ariaLabel != ""
@@ -1117,6 +2116,12 @@ func scanForJobsWithPagination(page playwright.Page, baseURL *url.URL, maxPages
 	var allJobs []models.LinkData
 	seenURLs := make(map[string]bool)
 
+	cached := cachedPaginationPattern(baseURL)
+
+	// Load-more buttons and infinite scroll grow the first page in place, so
+	// they have to run before the anchors are collected
+	expanded := expandJobList(page, cached)
+
 	// Scan first page
 	jobs := scanForJobs(page, baseURL)
 	for _, job := range jobs {
@@ -1129,9 +2134,35 @@ func scanForJobsWithPagination(page playwright.Page, baseURL *url.URL, maxPages
 	logger.Info().Int("jobs_page_1", len(jobs)).Msg("Scanned first page")
 
 	// Discover pagination pattern
-	pattern, err := discoverPaginationPattern(page, baseURL)
-	if err != nil {
-		logger.Warn().Err(err).Msg("No pagination found, returning first page results")
+	var pattern *PaginationPattern
+	var err error
+	currentPage := 1
+	if cached != nil && (cached.Type == paginationTypeQuery || cached.Type == paginationTypePath || cached.Type == paginationTypeClick) {
+		logger.Info().Str("type", cached.Type).Str("param", cached.ParamName).Msg("Using cached pagination pattern")
+		pattern = cached
+	} else {
+		pattern, err = discoverPaginationPattern(page, baseURL)
+		if err != nil {
+			if expanded != nil {
+				// Remember the in-place strategy so the next scan goes straight to it
+				cachePaginationPattern(baseURL, expanded)
+				logger.Info().Str("type", expanded.Type).Int("total_jobs", len(allJobs)).Msg("Job list expanded in place, no URL pagination")
+				return allJobs
+			}
+			logger.Warn().Err(err).Msg("No pagination found, returning first page results")
+			return allJobs
+		}
+		cachePaginationPattern(baseURL, pattern)
+
+		// Discovering a click board leaves it showing page 2
+		if pattern.Type == paginationTypeClick {
+			currentPage = 2
+		}
+	}
+
+	if pattern.Type == paginationTypeClick {
+		allJobs = scanWithClickPagination(page, baseURL, pattern, currentPage, maxPages, allJobs, seenURLs)
+		logger.Info().Int("total_jobs", len(allJobs)).Msg("Completed click pagination scan")
 		return allJobs
 	}
 
@@ -1152,6 +2183,7 @@ func scanForJobsWithPagination(page playwright.Page, baseURL *url.URL, maxPages
 			logger.Error().Err(err).Int("page", pageNum).Msg("Failed to navigate")
 			break
 		}
+		dismissConsentBanners(page)
 
 		// Scan for jobs on this page
 		jobs = scanForJobs(page, baseURL)
@@ -1184,20 +2216,266 @@ func scanForJobsWithPagination(page playwright.Page, baseURL *url.URL, maxPages
 	return allJobs
 }
 
+/* ================= CLICK PAGINATION ================= */
+
+// scanWithClickPagination keeps the page open and walks an SPA board by
+// clicking its next control, or each page's numbered control, scanning each
+// rendered state until a click stops changing the list or yields no new jobs.
+// currentPage is the page already on screen; past page 1 it is scanned as is.
+func scanWithClickPagination(page playwright.Page, baseURL *url.URL, pattern *PaginationPattern, currentPage int, maxPages int, allJobs []models.LinkData, seenURLs map[string]bool) []models.LinkData {
+	scanRendered := func(pageNum int) int {
+		newJobs := 0
+		for _, job := range scanForJobs(page, baseURL) {
+			if !seenURLs[job.URL] {
+				seenURLs[job.URL] = true
+				allJobs = append(allJobs, job)
+				newJobs++
+			}
+		}
+		logger.Info().Int("page", pageNum).Int("new_jobs", newJobs).Int("total", len(allJobs)).Msg("Scanned clicked page")
+		return newJobs
+	}
+
+	if currentPage > 1 && scanRendered(currentPage) == 0 {
+		logger.Info().Int("page", currentPage).Msg("No new jobs, reached end")
+		return allJobs
+	}
+
+	for pageNum := currentPage + 1; pageNum <= maxPages; pageNum++ {
+		selector := pattern.clickSelector(pageNum)
+		if selector == "" {
+			logger.Info().Int("page", pageNum).Msg("No control for the next page, reached end")
+			break
+		}
+
+		control := page.Locator(selector).First()
+		if count, err := control.Count(); err != nil || count == 0 {
+			logger.Info().Int("page", pageNum).Msg("Next control gone, reached end")
+			break
+		}
+		if disabled, _ := control.IsDisabled(); disabled {
+			logger.Info().Int("page", pageNum).Msg("Next control disabled, reached end")
+			break
+		}
+
+		signature := jobListSignature(page)
+
+		control.ScrollIntoViewIfNeeded()
+		if err := control.Click(playwright.LocatorClickOptions{
+			Timeout: playwright.Float(5000),
+		}); err != nil {
+			logger.Warn().Err(err).Int("page", pageNum).Msg("Failed to click next control")
+			break
+		}
+
+		if !waitForListChange(page, signature) {
+			logger.Info().Int("page", pageNum).Msg("Listing did not change after click, reached end")
+			break
+		}
+
+		if scanRendered(pageNum) == 0 {
+			logger.Info().Int("page", pageNum).Msg("No new jobs, reached end")
+			break
+		}
+	}
+
+	return allJobs
+}
+
+// waitForListChange polls until the anchor signature differs from before.
+func waitForListChange(page playwright.Page, before string) bool {
+	deadline := time.Now().Add(clickPageTimeout)
+	for time.Now().Before(deadline) {
+		page.WaitForTimeout(500)
+		if jobListSignature(page) != before {
+			// Let the rest of the list finish rendering
+			page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+				State:   playwright.LoadStateNetworkidle,
+				Timeout: playwright.Float(5000),
+			})
+			return true
+		}
+	}
+	return false
+}
+
+/* ================= LOAD MORE / INFINITE SCROLL ================= */
+
+// expandJobList grows an in-place job list by clicking "Load more" style
+// buttons and then scrolling until the page stops growing. A cached pattern
+// limits the work to the strategy that succeeded last time. The returned
+// pattern is nil when neither strategy loaded anything new.
+func expandJobList(page playwright.Page, cached *PaginationPattern) *PaginationPattern {
+	if cached != nil && (cached.Type == paginationTypeQuery || cached.Type == paginationTypePath || cached.Type == paginationTypeClick) {
+		return nil
+	}
+
+	deadline := time.Now().Add(expandTimeBudget)
+
+	if cached == nil || cached.Type == paginationTypeLoadMore {
+		if pattern := clickLoadMore(page, deadline); pattern != nil {
+			return pattern
+		}
+	}
+
+	if cached == nil || cached.Type == paginationTypeInfiniteScroll {
+		if pattern := scrollUntilStable(page, deadline); pattern != nil {
+			return pattern
+		}
+	}
+
+	return nil
+}
+
+// clickLoadMore clicks the first visible "Load more" control until it
+// disappears, stops adding anchors, or a safety limit is hit.
+func clickLoadMore(page playwright.Page, deadline time.Time) *PaginationPattern {
+	js := `
+	() => {
+		const K = %s;
+		document.querySelectorAll('[data-jobloop-load-more]').forEach(el => el.removeAttribute('data-jobloop-load-more'));
+
+		for (const el of document.querySelectorAll('button, a, [role="button"]')) {
+			const t = (el.innerText || el.getAttribute('aria-label') || '').trim().toLowerCase();
+			if (!t || t.length > 40 || !K.some(k => t.includes(k))) continue;
+			if (el.disabled || el.getAttribute('aria-disabled') === 'true') continue;
+
+			const href = el.getAttribute('href');
+			if (href && !href.startsWith('#') && !href.startsWith('javascript')) continue;
+
+			const r = el.getBoundingClientRect();
+			const s = window.getComputedStyle(el);
+			if (r.width === 0 || r.height === 0 || s.visibility === 'hidden' || s.display === 'none') continue;
+
+			el.setAttribute('data-jobloop-load-more', '1');
+			return el.innerText.trim() || t;
+		}
+		return null;
+	}
+	`
+	findButton := fmt.Sprintf(js, toJSArray(loadMoreKeywords))
+
+	clicks := 0
+	stalled := 0
+	buttonText := ""
+	previous := countAnchors(page)
+	initial := previous
+
+	for clicks < maxLoadMoreClicks && time.Now().Before(deadline) {
+		res, err := page.Evaluate(findButton)
+		if err != nil || res == nil {
+			break
+		}
+		buttonText = toString(res)
+
+		button := page.Locator("[data-jobloop-load-more]").First()
+		button.ScrollIntoViewIfNeeded()
+		if err := button.Click(playwright.LocatorClickOptions{
+			Timeout: playwright.Float(5000),
+		}); err != nil {
+			logger.Debug().Err(err).Str("button", buttonText).Msg("Load more click failed")
+			break
+		}
+		clicks++
+
+		page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
+			State:   playwright.LoadStateNetworkidle,
+			Timeout: playwright.Float(5000),
+		})
+		page.WaitForTimeout(float64(expandSettleWait.Milliseconds()))
+
+		current := countAnchors(page)
+		if current <= previous {
+			stalled++
+			if stalled >= 2 {
+				break
+			}
+		} else {
+			stalled = 0
+		}
+		previous = current
+	}
+
+	if previous <= initial {
+		return nil
+	}
+
+	logger.Info().Str("button", buttonText).Int("clicks", clicks).Int("anchors_before", initial).Int("anchors_after", previous).Msg("Expanded job list with load more")
+
+	return &PaginationPattern{
+		Type:         paginationTypeLoadMore,
+		LoadMoreText: buttonText,
+	}
+}
+
+// scrollUntilStable scrolls to the bottom repeatedly until neither the page
+// height nor the anchor count changes.
+func scrollUntilStable(page playwright.Page, deadline time.Time) *PaginationPattern {
+	initial := countAnchors(page)
+	previousAnchors := initial
+	previousHeight := pageHeight(page)
+	stable := 0
+	rounds := 0
+
+	for rounds < maxScrollRounds && time.Now().Before(deadline) {
+		page.Evaluate(`() => window.scrollTo(0, document.body.scrollHeight)`)
+		rounds++
+
+		page.WaitForTimeout(float64(expandSettleWait.Milliseconds()))
+
+		anchors := countAnchors(page)
+		height := pageHeight(page)
+		if anchors <= previousAnchors && height <= previousHeight {
+			stable++
+			if stable >= 2 {
+				break
+			}
+		} else {
+			stable = 0
+		}
+		previousAnchors = anchors
+		previousHeight = height
+	}
+
+	page.Evaluate(`() => window.scrollTo(0, 0)`)
+
+	if previousAnchors <= initial {
+		return nil
+	}
+
+	logger.Info().Int("rounds", rounds).Int("anchors_before", initial).Int("anchors_after", previousAnchors).Msg("Expanded job list with infinite scroll")
+
+	return &PaginationPattern{
+		Type: paginationTypeInfiniteScroll,
+	}
+}
+
+func countAnchors(page playwright.Page) int {
+	res, err := page.Evaluate(`() => document.querySelectorAll("a[href]").length`)
+	if err != nil {
+		return 0
+	}
+	return toInt(res)
+}
+
+func pageHeight(page playwright.Page) int {
+	res, err := page.Evaluate(`() => document.body ? document.body.scrollHeight : 0`)
+	if err != nil {
+		return 0
+	}
+	return toInt(res)
+}
+
 /* ================= HASH / SPA WAIT ================= */
 
 func waitForJobContent(page playwright.Page) {
-	page.WaitForFunction(`
+	page.WaitForFunction(fmt.Sprintf(`
 	() => {
+		const K = %s;
 		const t = document.body.innerText.toLowerCase();
-		return (
-			t.includes("engineer") ||
-			t.includes("developer") ||
-			t.includes("manager") ||
-			t.includes("senior")
-		);
-	}
-	`, playwright.PageWaitForFunctionOptions{
+		return K.some(k => t.includes(k));
+	}
+	`, toJSArray(pageKeywords(page).Content)), playwright.PageWaitForFunctionOptions{
 		Timeout: playwright.Float(float64(jobWaitTimeout.Milliseconds())),
 	})
 }
@@ -1236,11 +2514,92 @@ func logJobs(jobs []models.LinkData) {
 
 func toJSArray(arr []string) string {
 	logger.Info().Msg("converting to JS array in toJSArray func")
-	q := make([]string, len(arr))
-	for i, s := range arr {
-		q[i] = `"` + s + `"`
+	if arr == nil {
+		arr = []string{}
+	}
+	return toJSON(arr)
+}
+
+func toJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "null"
+	}
+	return string(b)
+}
+
+// registrableDomain returns the eTLD+1 of a host ("acme.co.uk" for
+// "careers.acme.co.uk"), or the bare host when it has none.
+func registrableDomain(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+	if host == "" {
+		return ""
+	}
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return strings.TrimPrefix(host, "www.")
+	}
+	return domain
+}
+
+// normalizeCompanyName lowercases a company name and drops punctuation,
+// whitespace and common legal suffixes so names can be compared loosely.
+func normalizeCompanyName(name string) string {
+	return strings.Join(companyNameWords(name), "")
+}
+
+// companyNameWords splits a name into lowercase words without legal suffixes
+// such as "inc" or "gmbh".
+func companyNameWords(name string) []string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var kept []string
+	for _, w := range words {
+		if !companySuffixes[w] {
+			kept = append(kept, w)
+		}
+	}
+	return kept
+}
+
+// containsNameWords reports whether the company name appears in text as a
+// run of whole words, so "Ramp" matches "Careers at Ramp" but not "Programp".
+func containsNameWords(text string, companyName string) bool {
+	name := companyNameWords(companyName)
+	if len(name) == 0 {
+		return false
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i := 0; i+len(name) <= len(words); i++ {
+		match := true
+		for j, w := range name {
+			if words[i+j] != w {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
 	}
-	return "[" + strings.Join(q, ",") + "]"
+	return 0
 }
 
 func toString(v interface{}) string {
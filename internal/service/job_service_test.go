@@ -0,0 +1,142 @@
+package service
+
+import (
+	"net/url"
+	"regexp"
+	"testing"
+)
+
+func TestATSBoardMatchesSlug(t *testing.T) {
+	slugs := companySlugs("Acme Robotics", "acmebots.com")
+
+	tests := []struct {
+		name  string
+		board string
+		want  bool
+	}{
+		{"greenhouse path", "https://boards.greenhouse.io/acmerobotics", true},
+		{"lever hyphenated path", "https://jobs.lever.co/acme-robotics/", true},
+		{"bamboohr subdomain", "https://acmebots.bamboohr.com/careers", true},
+		{"personio subdomain", "https://acmebots.jobs.personio.de", true},
+		{"someone else's board", "https://boards.greenhouse.io/globex", false},
+		{"slug inside a longer segment", "https://jobs.lever.co/acmeroboticsltd", false},
+		{"vendor homepage", "https://www.greenhouse.io/", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, err := url.Parse(tt.board)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !isATSHost(board.Hostname()) {
+				t.Fatalf("isATSHost(%q) = false, want true", board.Hostname())
+			}
+			if got := atsBoardMatchesSlug(board, slugs); got != tt.want {
+				t.Errorf("atsBoardMatchesSlug(%q) = %v, want %v", tt.board, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsATSHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"boards.greenhouse.io", true},
+		{"acme.wd5.myworkdayjobs.com", true},
+		{"acme.jobs.personio.com", true},
+		{"app.acme.com", false},
+		{"notgreenhouse.io", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := isATSHost(tt.host); got != tt.want {
+				t.Errorf("isATSHost(%q) = %v, want %v", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreCrawlSignals(t *testing.T) {
+	keywords := localeCatalogue["en"]
+
+	tests := []struct {
+		name    string
+		signals crawlSignals
+		pageURL string
+		want    int
+	}{
+		{
+			name:    "busy navigation without a strong signal",
+			signals: crawlSignals{JobAnchors: 12, RepeatedGroup: 6},
+			pageURL: "https://acme.com/platform",
+			want:    0,
+		},
+		{
+			name:    "careers path",
+			signals: crawlSignals{JobAnchors: 4, RepeatedGroup: 3},
+			pageURL: "https://acme.com/careers/open-roles",
+			want:    13,
+		},
+		{
+			name:    "ATS embed",
+			signals: crawlSignals{ATS: true},
+			pageURL: "https://acme.com/team",
+			want:    10,
+		},
+		{
+			name:    "JobPosting markup",
+			signals: crawlSignals{JobPosting: true, JobAnchors: 1},
+			pageURL: "https://acme.com/about",
+			want:    16,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scoreCrawlSignals(tt.signals, tt.pageURL, keywords); got != tt.want {
+				t.Errorf("scoreCrawlSignals = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrawlTitleKeywordsDropGenericWords(t *testing.T) {
+	titles := crawlTitleKeywords(localeCatalogue["en"])
+	for _, k := range titles {
+		if crawlGenericTitleWords[k] {
+			t.Errorf("crawl title keywords include generic word %q", k)
+		}
+	}
+	if !containsAny("senior backend engineer", titles) {
+		t.Errorf("crawl title keywords %q do not match an engineering title", titles)
+	}
+}
+
+func TestCrawlApplyURLPattern(t *testing.T) {
+	apply := regexp.MustCompile("(?i)" + crawlApplyURLPattern)
+
+	tests := []struct {
+		href string
+		want bool
+	}{
+		{"https://acme.com/jobs/4021", true},
+		{"https://acme.com/careers/backend-engineer", true},
+		{"https://acme.com/careers?gh_jid=4021", true},
+		{"https://acme.com/positions/sre/apply", true},
+		{"https://acme.com/careers/", false},
+		{"https://acme.com/platform/solutions", false},
+		{"https://acme.com/customers/globex", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.href, func(t *testing.T) {
+			if got := apply.MatchString(tt.href); got != tt.want {
+				t.Errorf("apply pattern on %q = %v, want %v", tt.href, got, tt.want)
+			}
+		})
+	}
+}
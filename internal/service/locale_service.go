@@ -0,0 +1,183 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+
+	"github.com/chandhuDev/JobLoop/internal/logger"
+)
+
+// LocaleKeywords is the vocabulary used to find careers pages and job
+// listings on a site written in one language.
+type LocaleKeywords struct {
+	Careers   []string // careers link text, matched anywhere in a label
+	CTAs      []string // "view all jobs" style call-to-action text, never a single job's apply button
+	Paths     []string // common careers paths on the company host
+	Content   []string // words that show a job list has rendered
+	JobTitles []string // words that mark an anchor as a job posting
+}
+
+/* ================= CATALOGUE ================= */
+
+var localeCatalogue = map[string]LocaleKeywords{
+	"en": {
+		Careers:   careerKeywords,
+		CTAs:      ctaKeywords,
+		Paths:     []string{"/careers", "/jobs", "/careers/", "/jobs/", "/en/careers", "/about/careers", "/company/careers"},
+		Content:   []string{"engineer", "developer", "manager", "senior"},
+		JobTitles: jobKeywords,
+	},
+	"de": {
+		Careers:   []string{"karriere", "stellenangebote", "offene stellen", "jobs bei", "arbeiten bei"},
+		CTAs:      []string{"alle stellen", "offene stellen", "stellenangebote ansehen", "alle jobs", "jobs ansehen", "zu den stellen"},
+		Paths:     []string{"/karriere", "/de/karriere", "/stellenangebote", "/de/jobs", "/jobs-karriere"},
+		Content:   []string{"(m/w/d)", "(w/m/d)", "entwickler", "ingenieur", "werkstudent", "vollzeit"},
+		JobTitles: []string{"entwickler", "ingenieur", "(m/w/d)", "(w/m/d)", "(m/f/d)", "leiter", "berater", "werkstudent", "praktikum", "referent", "sachbearbeiter"},
+	},
+	"fr": {
+		Careers:   []string{"carrières", "carrieres", "carrière", "emplois", "recrutement", "rejoignez-nous", "nous rejoindre", "offres d'emploi"},
+		CTAs:      []string{"voir les offres", "voir toutes les offres", "nos offres", "toutes nos offres", "voir les postes", "postes ouverts"},
+		Paths:     []string{"/carrieres", "/fr/carrieres", "/recrutement", "/emplois", "/nous-rejoindre", "/fr/careers"},
+		Content:   []string{"ingénieur", "développeur", "h/f", "f/h", "cdi", "stage"},
+		JobTitles: []string{"ingénieur", "développeur", "h/f", "f/h", "chef de projet", "responsable", "stagiaire", "alternance", "chargé", "consultant"},
+	},
+	"es": {
+		Careers:   []string{"empleo", "empleos", "trabaja con nosotros", "carreras", "únete", "vacantes", "ofertas de trabajo"},
+		CTAs:      []string{"ver vacantes", "ver ofertas", "ver todas las ofertas", "ver empleos", "posiciones abiertas", "buscar empleo"},
+		Paths:     []string{"/empleo", "/es/empleo", "/trabaja-con-nosotros", "/carreras", "/vacantes"},
+		Content:   []string{"ingeniero", "desarrollador", "gerente", "vacante", "jornada completa"},
+		JobTitles: []string{"ingeniero", "desarrollador", "gerente", "analista", "jefe", "responsable", "técnico", "becario", "consultor"},
+	},
+	"pt": {
+		Careers:   []string{"vagas", "carreiras", "carreira", "trabalhe conosco", "trabalhe connosco", "faça parte"},
+		CTAs:      []string{"ver vagas", "ver todas as vagas", "vagas abertas", "veja as vagas", "conheça as vagas"},
+		Paths:     []string{"/vagas", "/carreiras", "/trabalhe-conosco", "/pt/carreiras", "/pt-br/carreiras"},
+		Content:   []string{"engenheiro", "desenvolvedor", "analista", "vaga", "estágio"},
+		JobTitles: []string{"engenheiro", "desenvolvedor", "analista", "gerente", "coordenador", "estagiário", "estágio", "especialista", "consultor"},
+	},
+	"it": {
+		Careers:   []string{"lavora con noi", "carriere", "carriera", "posizioni aperte", "opportunità di lavoro"},
+		CTAs:      []string{"vedi le posizioni", "tutte le posizioni", "posizioni aperte", "scopri le offerte"},
+		Paths:     []string{"/lavora-con-noi", "/carriere", "/it/carriere", "/it/lavora-con-noi"},
+		Content:   []string{"ingegnere", "sviluppatore", "tempo pieno"},
+		JobTitles: []string{"ingegnere", "sviluppatore", "responsabile", "tecnico", "stage", "consulente", "analista"},
+	},
+	"nl": {
+		Careers:   []string{"vacatures", "werken bij", "carrière", "werkenbij", "kom werken"},
+		CTAs:      []string{"bekijk vacatures", "alle vacatures", "bekijk alle vacatures", "open vacatures"},
+		Paths:     []string{"/vacatures", "/werken-bij", "/nl/vacatures", "/werkenbij"},
+		Content:   []string{"ontwikkelaar", "medewerker", "fulltime", "vacature"},
+		JobTitles: []string{"ontwikkelaar", "medewerker", "adviseur", "stagiair", "beheerder", "specialist"},
+	},
+	"pl": {
+		Careers:   []string{"kariera", "oferty pracy", "dołącz do nas"},
+		CTAs:      []string{"zobacz oferty", "wszystkie oferty", "otwarte rekrutacje"},
+		Paths:     []string{"/kariera", "/praca", "/pl/kariera"},
+		Content:   []string{"programista", "inżynier", "specjalista"},
+		JobTitles: []string{"programista", "inżynier", "specjalista", "kierownik", "analityk", "stażysta"},
+	},
+	"sv": {
+		Careers:   []string{"karriär", "lediga jobb", "jobba hos oss", "lediga tjänster"},
+		CTAs:      []string{"se lediga jobb", "alla lediga jobb", "visa alla jobb", "sök jobb"},
+		Paths:     []string{"/karriar", "/jobb", "/lediga-jobb", "/sv/karriar"},
+		Content:   []string{"utvecklare", "ingenjör", "heltid"},
+		JobTitles: []string{"utvecklare", "ingenjör", "chef", "specialist", "konsult", "praktikant"},
+	},
+	"ja": {
+		Careers:   []string{"採用", "採用情報", "求人", "キャリア", "募集職種", "リクルート"},
+		CTAs:      []string{"募集職種", "求人一覧", "募集中の職種", "職種一覧"},
+		Paths:     []string{"/recruit", "/recruit/", "/saiyo", "/jp/careers", "/ja/careers", "/careers/jp"},
+		Content:   []string{"エンジニア", "募集", "正社員", "職種"},
+		JobTitles: []string{"エンジニア", "開発", "マネージャー", "デザイナー", "正社員", "契約社員", "営業", "募集"},
+	},
+	"zh": {
+		Careers:   []string{"招聘", "加入我们", "人才招聘", "职位", "工作机会"},
+		CTAs:      []string{"查看职位", "所有职位", "社会招聘", "校园招聘"},
+		Paths:     []string{"/join", "/jobs", "/zh/careers", "/careers/zh"},
+		Content:   []string{"工程师", "开发", "经理", "职位"},
+		JobTitles: []string{"工程师", "开发", "经理", "设计师", "专员", "实习"},
+	},
+	"ko": {
+		Careers:   []string{"채용", "인재채용", "채용정보", "커리어"},
+		CTAs:      []string{"채용 공고", "전체 공고", "공고 보기"},
+		Paths:     []string{"/recruit", "/careers/ko", "/ko/careers"},
+		Content:   []string{"엔지니어", "개발자", "채용"},
+		JobTitles: []string{"엔지니어", "개발자", "매니저", "디자이너", "인턴"},
+	},
+}
+
+// ExtendLocaleCatalogue adds configured keywords to a language's catalogue
+// entry, creating the entry for languages the catalogue does not cover. Call
+// it before scraping starts.
+func ExtendLocaleCatalogue(lang string, extra LocaleKeywords) {
+	base := localeCatalogue[lang]
+	localeCatalogue[lang] = LocaleKeywords{
+		Careers:   mergeKeywords(base.Careers, extra.Careers),
+		CTAs:      mergeKeywords(base.CTAs, extra.CTAs),
+		Paths:     mergeKeywords(base.Paths, extra.Paths),
+		Content:   mergeKeywords(base.Content, extra.Content),
+		JobTitles: mergeKeywords(base.JobTitles, extra.JobTitles),
+	}
+}
+
+/* ================= LOOKUP ================= */
+
+// detectPageLanguage returns the primary subtag of the page's lang attribute
+// ("de" for "de-DE"), or "en" when the page does not declare one.
+func detectPageLanguage(page playwright.Page) string {
+	res, err := page.Evaluate(`() => document.documentElement.getAttribute("lang") || ""`)
+	if err != nil {
+		return "en"
+	}
+
+	lang := strings.ToLower(strings.TrimSpace(toString(res)))
+	if idx := strings.IndexAny(lang, "-_"); idx != -1 {
+		lang = lang[:idx]
+	}
+	if lang == "" {
+		return "en"
+	}
+	return lang
+}
+
+// keywordsFor returns the catalogue entry for a language merged with the
+// English one, since non-English sites still commonly link "Careers" or "Jobs".
+func keywordsFor(lang string) LocaleKeywords {
+	en := localeCatalogue["en"]
+	local, ok := localeCatalogue[lang]
+	if lang == "en" || !ok {
+		return en
+	}
+
+	return LocaleKeywords{
+		Careers:   mergeKeywords(local.Careers, en.Careers),
+		CTAs:      mergeKeywords(local.CTAs, en.CTAs),
+		Paths:     mergeKeywords(local.Paths, en.Paths),
+		Content:   mergeKeywords(local.Content, en.Content),
+		JobTitles: mergeKeywords(local.JobTitles, en.JobTitles),
+	}
+}
+
+// pageKeywords detects the page language and returns its keywords.
+func pageKeywords(page playwright.Page) LocaleKeywords {
+	lang := detectPageLanguage(page)
+	if _, ok := localeCatalogue[lang]; ok && lang != "en" {
+		logger.Info().Str("lang", lang).Msg("Using localized keywords")
+	}
+	return keywordsFor(lang)
+}
+
+func mergeKeywords(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, list := range lists {
+		for _, k := range list {
+			if !seen[k] {
+				seen[k] = true
+				out = append(out, k)
+			}
+		}
+	}
+	return out
+}
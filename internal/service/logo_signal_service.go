@@ -0,0 +1,246 @@
+package service
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	"github.com/chandhuDev/JobLoop/internal/models"
+)
+
+/* ================= CONFIG ================= */
+
+var (
+	// Words that describe the image rather than name the company
+	logoNoiseWords = map[string]bool{
+		"logo": true, "logos": true, "logotype": true, "wordmark": true, "icon": true, "image": true, "img": true,
+		"picture": true, "photo": true, "brand": true, "mark": true, "customer": true, "customers": true,
+		"client": true, "clients": true, "partner": true, "partners": true, "company": true, "of": true,
+		"color": true, "colour": true, "white": true, "black": true, "dark": true, "light": true, "mono": true,
+		"grey": true, "gray": true, "full": true, "horizontal": true, "vertical": true, "rgb": true, "cmyk": true,
+		"svg": true, "png": true, "jpg": true, "webp": true, "asset": true, "assets": true, "final": true,
+		"new": true, "small": true, "large": true, "default": true, "primary": true, "original": true,
+		"go": true, "to": true, "visit": true, "website": true, "homepage": true, "read": true, "view": true,
+		"story": true, "case": true, "study": true, "sprite": true, "sprites": true,
+	}
+
+	// Words that mark a label as a person, a quote or a sentence rather than a
+	// company name
+	logoRejectWords = map[string]bool{
+		"headshot": true, "avatar": true, "portrait": true, "testimonial": true, "testimonials": true,
+		"quote": true, "profile": true, "author": true, "founder": true, "ceo": true, "cto": true,
+		"the": true, "a": true, "an": true, "and": true, "from": true, "by": true, "with": true,
+		"for": true, "our": true, "at": true, "in": true, "on": true, "is": true, "placeholder": true,
+	}
+
+	// Words that say the image is a logo, so a lowercase label still names it
+	logoContextWords = map[string]bool{
+		"logo": true, "logos": true, "logotype": true, "wordmark": true,
+	}
+
+	// Content hashes: long hex runs, or long runs mixing letters and digits.
+	// Short mixed tokens ("1password", "acme2024") are names.
+	hexRunRegex   = regexp.MustCompile(`^[0-9a-f]{8,}$`)
+	mixedRunRegex = regexp.MustCompile(`^[0-9a-z]{12,}$`)
+	numberRegex   = regexp.MustCompile(`^\d+$`)
+	sizeRegex     = regexp.MustCompile(`^\d+x\d*$|^@\dx$`)
+
+	maxSignalWords = 4
+)
+
+/* ================= MAIN ================= */
+
+// SplitBySignals resolves names from each image's alt text, aria-label,
+// title, link target or filename, strongest first. Images with no usable
+// signal are returned for vision OCR.
+func SplitBySignals(images []models.TestimonialImage, companyURL string) ([]string, []models.TestimonialImage) {
+	var names []string
+	var unresolved []models.TestimonialImage
+
+	for _, img := range images {
+		name, signal := nameFromSignals(img, companyURL)
+		if name == "" {
+			unresolved = append(unresolved, img)
+			continue
+		}
+
+		logger.Debug().Str("url", img.URL).Str("signal", signal).Str("name", name).Msg("resolved logo without OCR")
+		names = append(names, name)
+	}
+
+	return names, unresolved
+}
+
+// nameFromSignals trusts a link to another site, a single proper noun, or a
+// label or filename that calls the image a logo. Anything weaker, such as a
+// bare filename ("arrow-right.svg") or a person's name in the alt text, is
+// left to vision.
+func nameFromSignals(img models.TestimonialImage, companyURL string) (string, string) {
+	fileName, fileContext := nameFromFilename(img.Filename)
+
+	for _, signal := range []struct {
+		name  string
+		value string
+	}{
+		{"alt", img.Alt},
+		{"aria-label", img.AriaLabel},
+		{"title", img.Title},
+	} {
+		if name := nameFromText(signal.value, fileContext); name != "" {
+			return name, signal.name
+		}
+	}
+
+	if name := nameFromLink(img.LinkHref, companyURL); name != "" {
+		return name, "link"
+	}
+
+	if fileName != "" && fileContext {
+		return fileName, "filename"
+	}
+
+	return "", ""
+}
+
+/* ================= SIGNALS ================= */
+
+// nameFromText keeps the words of an alt/title/aria-label once descriptive
+// words ("logo", "customer", "white") are dropped. Unless the label or the
+// filename (logoContext) calls the image a logo, the rest must be a single
+// proper noun: "Sarah Chen" is as likely a headshot as a company. Labels
+// describing people or sentences ("Headshot", "Testimonial from Jane") are
+// rejected.
+func nameFromText(text string, logoContext bool) string {
+	words := strings.Fields(strings.Join(strings.FieldsFunc(text, func(r rune) bool {
+		return r == '|' || r == '-' || r == '_' || r == ':'
+	}), " "))
+
+	var kept []string
+	for _, w := range words {
+		trimmed := strings.Trim(w, ".,;()[]\"'")
+		lower := strings.ToLower(trimmed)
+		if logoRejectWords[lower] {
+			return ""
+		}
+		if logoContextWords[lower] {
+			logoContext = true
+		}
+		if lower == "" || logoNoiseWords[lower] || sizeRegex.MatchString(lower) {
+			continue
+		}
+		if strings.IndexFunc(lower, unicode.IsLetter) == -1 {
+			continue
+		}
+		kept = append(kept, trimmed)
+	}
+
+	if len(kept) == 0 || len(kept) > maxSignalWords {
+		return ""
+	}
+	if !logoContext {
+		if len(kept) > 1 {
+			return ""
+		}
+		for _, w := range kept {
+			if !looksProper(w) {
+				return ""
+			}
+		}
+	}
+	return normalizeRecognizedName(strings.Join(kept, " "))
+}
+
+// looksProper accepts "Stripe", "eBay" and "3M" but not "headshot".
+func looksProper(word string) bool {
+	for _, r := range word {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return unicode.IsDigit([]rune(word)[0])
+}
+
+// nameFromLink names the logo after the site it links to, skipping links back
+// to the company's own site and to directories or social networks.
+func nameFromLink(href string, companyURL string) string {
+	parsed, err := url.Parse(href)
+	if err != nil || parsed.Hostname() == "" {
+		return ""
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	domain := registrableDomain(host)
+	if domain == "" || isDirectoryHost(host) {
+		return ""
+	}
+
+	if own, err := url.Parse(companyURL); err == nil && own.Hostname() != "" {
+		if registrableDomain(strings.ToLower(own.Hostname())) == domain {
+			return ""
+		}
+	}
+
+	label := domain
+	if idx := strings.Index(label, "."); idx > 0 {
+		label = label[:idx]
+	}
+	if len(label) < 2 {
+		return ""
+	}
+	return titleWord(label)
+}
+
+// nameFromFilename reads names like "stripe.svg" or "logo-acme-white@2x.png"
+// and ignores hashed or numbered asset names. It also reports whether the
+// filename calls the image a logo; without that the name is only a guess.
+func nameFromFilename(filename string) (string, bool) {
+	base := strings.ToLower(strings.TrimSuffix(filename, path.Ext(filename)))
+	if base == "" {
+		return "", false
+	}
+
+	var kept []string
+	logoContext := false
+	for _, token := range strings.FieldsFunc(base, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == ' ' || r == '+' || r == '@'
+	}) {
+		if logoContextWords[token] {
+			logoContext = true
+		}
+		if logoNoiseWords[token] || sizeRegex.MatchString(token) {
+			continue
+		}
+		if isHashLike(token) {
+			return "", false
+		}
+		kept = append(kept, titleWord(token))
+	}
+
+	if len(kept) == 0 || len(kept) > maxSignalWords-1 {
+		return "", logoContext
+	}
+	return normalizeRecognizedName(strings.Join(kept, " ")), logoContext
+}
+
+// isHashLike spots content hashes and numbered assets ("a3f9c2e81b",
+// "logo-12"), which say nothing about the company.
+func isHashLike(token string) bool {
+	if hexRunRegex.MatchString(token) || numberRegex.MatchString(token) {
+		return true
+	}
+	return mixedRunRegex.MatchString(token) &&
+		strings.IndexFunc(token, unicode.IsDigit) >= 0 &&
+		strings.IndexFunc(token, unicode.IsLetter) >= 0
+}
+
+func titleWord(w string) string {
+	if w == "" {
+		return w
+	}
+	r := []rune(w)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
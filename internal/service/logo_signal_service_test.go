@@ -0,0 +1,121 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/chandhuDev/JobLoop/internal/models"
+)
+
+func TestNameFromText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		context bool
+		want    string
+	}{
+		{"plain name", "Stripe", false, "Stripe"},
+		{"logo suffix", "Stripe logo", false, "Stripe"},
+		{"descriptive words dropped", "Customer logo - Acme Robotics (white)", false, "Acme Robotics"},
+		{"separators", "Notion | Customers", false, "Notion"},
+		{"camel case", "eBay", false, "eBay"},
+		{"leading digit", "3M", false, "3M"},
+		{"lowercase with logo context", "stripe logo", false, "stripe"},
+		{"lowercase without logo context", "happy customer", false, ""},
+		{"generic label", "image", false, ""},
+		{"person", "Headshot of Jane Doe", false, ""},
+		{"sentence", "Testimonial from the Acme team", false, ""},
+		{"quote attribution", "Jane Doe, CEO at Acme", false, ""},
+		{"too many words", "Acme Robotics Global Holdings Group", true, ""},
+		{"size only", "logo 200x80", false, ""},
+		{"person without logo context", "Sarah Chen", false, ""},
+		{"two words without logo context", "Acme Robotics", false, ""},
+		{"two words with a logo filename", "Acme Robotics", true, "Acme Robotics"},
+		{"empty", "", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nameFromText(tt.text, tt.context); got != tt.want {
+				t.Errorf("nameFromText(%q, %v) = %q, want %q", tt.text, tt.context, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameFromFilename(t *testing.T) {
+	tests := []struct {
+		name        string
+		filename    string
+		want        string
+		wantContext bool
+	}{
+		{"plain", "stripe.svg", "Stripe", false},
+		{"noise and retina suffix", "logo-acme-white@2x.png", "Acme", true},
+		{"two words", "acme_robotics-logo.png", "Acme Robotics", true},
+		{"size token", "notion-200x80.webp", "Notion", false},
+		{"name with digits", "1password.svg", "1password", false},
+		{"year in name", "acme2024.png", "Acme2024", false},
+		{"hex hash", "a3f9c2e81b.png", "", false},
+		{"long mixed hash", "k3j9x0p2q8w7z1.png", "", false},
+		{"hash after name", "stripe-5f2b9c7d.png", "", false},
+		{"numbered asset", "logo-12.png", "", false},
+		{"only noise", "logo-white.png", "", true},
+		{"empty", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, context := nameFromFilename(tt.filename)
+			if got != tt.want || context != tt.wantContext {
+				t.Errorf("nameFromFilename(%q) = (%q, %v), want (%q, %v)", tt.filename, got, context, tt.want, tt.wantContext)
+			}
+		})
+	}
+}
+
+func TestNameFromLink(t *testing.T) {
+	tests := []struct {
+		name string
+		href string
+		want string
+	}{
+		{"customer site", "https://www.stripe.com/customers/acme", "Stripe"},
+		{"own site", "https://blog.acme.com/case-study", ""},
+		{"directory", "https://www.linkedin.com/company/stripe", ""},
+		{"relative link", "/customers/stripe", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nameFromLink(tt.href, "https://acme.com"); got != tt.want {
+				t.Errorf("nameFromLink(%q) = %q, want %q", tt.href, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameFromSignals(t *testing.T) {
+	tests := []struct {
+		name       string
+		img        models.TestimonialImage
+		want       string
+		wantSignal string
+	}{
+		{"icon filename", models.TestimonialImage{Filename: "arrow-right.svg"}, "", ""},
+		{"banner filename", models.TestimonialImage{Filename: "hero-banner.png"}, "", ""},
+		{"person filename", models.TestimonialImage{Filename: "jane-doe.jpg"}, "", ""},
+		{"person alt", models.TestimonialImage{Alt: "Sarah Chen", Filename: "sarah.jpg"}, "", ""},
+		{"logo filename", models.TestimonialImage{Filename: "logo-acme-white@2x.png"}, "Acme", "filename"},
+		{"alt backed by a logo filename", models.TestimonialImage{Alt: "Acme Robotics", Filename: "acme-robotics-logo.png"}, "Acme Robotics", "alt"},
+		{"link", models.TestimonialImage{Filename: "img-1.png", LinkHref: "https://stripe.com/customers"}, "Stripe", "link"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, signal := nameFromSignals(tt.img, "https://acme.com")
+			if got != tt.want || signal != tt.wantSignal {
+				t.Errorf("nameFromSignals = (%q, %q), want (%q, %q)", got, signal, tt.want, tt.wantSignal)
+			}
+		})
+	}
+}
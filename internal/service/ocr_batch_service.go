@@ -0,0 +1,384 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"gorm.io/gorm"
+
+	"github.com/chandhuDev/JobLoop/internal/interfaces"
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	models "github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/repository"
+	"github.com/chandhuDev/JobLoop/internal/schema"
+)
+
+/* ================= CONFIG ================= */
+
+const (
+	// How often the queue is checked against the time window
+	ocrQueueCheckInterval = 5 * time.Second
+
+	// Batch results are kept by the API for 29 days
+	ocrBatchResultsExpiry = 29 * 24 * time.Hour
+
+	// Items claimed this long ago without a recorded batch were left by a
+	// crash or a failed save and go back in the queue
+	ocrSubmitStaleAfter = 10 * time.Minute
+)
+
+// OCRBatcher queues OCR requests from every company in the database and
+// submits them as shared Message Batches once OCR_BATCH_SIZE images are
+// waiting or the oldest has waited OCR_BATCH_WINDOW. Run collects finished
+// batches every OCR_BATCH_POLL_INTERVAL, including batches submitted by an
+// earlier run, and stores the names against the company each image came from.
+type OCRBatcher struct {
+	Client       *anthropic.Client
+	Model        string
+	DB           *gorm.DB
+	Names        *models.NamesClient
+	Usage        interfaces.UsageRecorder
+	BatchSize    int
+	Window       time.Duration
+	PollInterval time.Duration
+
+	notify chan struct{}
+}
+
+func NewOCRBatcher(client *anthropic.Client, model string, db *gorm.DB, names *models.NamesClient, usage interfaces.UsageRecorder) *OCRBatcher {
+	size := envInt("OCR_BATCH_SIZE", 100)
+
+	return &OCRBatcher{
+		Client:       client,
+		Model:        model,
+		DB:           db,
+		Names:        names,
+		Usage:        usage,
+		BatchSize:    size,
+		Window:       envDuration("OCR_BATCH_WINDOW", 2*time.Minute),
+		PollInterval: envDuration("OCR_BATCH_POLL_INTERVAL", 30*time.Second),
+		notify:       make(chan struct{}, 1),
+	}
+}
+
+func (b *OCRBatcher) Name() string {
+	return "batch"
+}
+
+// Recognize submits the requests as a batch of their own and waits for it,
+// for callers that need the names straight away.
+func (b *OCRBatcher) Recognize(ctx context.Context, requests []models.OCRRequest) ([]models.OCRResult, error) {
+	return (&AnthropicBatchVision{Client: b.Client, Model: b.Model, Usage: b.Usage}).Recognize(ctx, requests)
+}
+
+// Enqueue stores the images for the next shared batch and returns at once.
+func (b *OCRBatcher) Enqueue(ctx context.Context, seedCompanyID uint, requests []models.OCRRequest) error {
+	items := make([]schema.OCRBatchItem, 0, len(requests))
+	for _, req := range requests {
+		items = append(items, schema.OCRBatchItem{
+			SeedCompanyID:  seedCompanyID,
+			ImageURL:       req.ImageURL,
+			ContentHash:    req.ContentHash,
+			PerceptualHash: int64(req.PerceptualHash),
+			MediaType:      req.MediaType,
+			Data:           req.Data,
+			Status:         models.OCRStatusQueued,
+		})
+	}
+
+	if err := repository.QueueOCRItems(b.DB.WithContext(ctx), items); err != nil {
+		return err
+	}
+
+	select {
+	case b.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+/* ================= LOOP ================= */
+
+// Run submits queued images and collects finished batches until ctx is
+// cancelled. Work left over is picked up by the next run.
+func (b *OCRBatcher) Run(ctx context.Context) {
+	logger.Info().Int("batch_size", b.BatchSize).Dur("window", b.Window).Dur("poll_interval", b.PollInterval).Msg("starting OCR batch collector")
+
+	queueTicker := time.NewTicker(ocrQueueCheckInterval)
+	defer queueTicker.Stop()
+	pollTicker := time.NewTicker(b.PollInterval)
+	defer pollTicker.Stop()
+
+	// A batch that was created but never recorded cannot be collected, so
+	// its images are sent again rather than left out for good
+	if stale, err := repository.RequeueStaleOCRItems(b.DB.WithContext(ctx), time.Now().Add(-ocrSubmitStaleAfter)); err != nil {
+		logger.Error().Err(err).Msg("failed to requeue stale OCR items")
+	} else if stale > 0 {
+		logger.Warn().Int64("items", stale).Msg("requeued OCR items whose batch was never recorded")
+	}
+
+	// Resume batches left open by an earlier run, holding their estimated
+	// cost against the budget until they are collected
+	if open, err := repository.ListOpenOCRBatches(b.DB.WithContext(ctx)); err == nil {
+		for _, batch := range open {
+			reserveUsage(b.Usage, batch.BatchID, estimateOCRBatchCost(b.Model, batch.RequestCount))
+		}
+	}
+	b.collect(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info().Msg("OCR batch collector stopping")
+			return
+		case <-b.notify:
+			b.submitQueued(ctx, false)
+		case <-queueTicker.C:
+			b.submitQueued(ctx, false)
+		case <-pollTicker.C:
+			b.collect(ctx)
+		}
+	}
+}
+
+// Flush submits everything queued regardless of size or window.
+func (b *OCRBatcher) Flush(ctx context.Context) {
+	b.submitQueued(ctx, true)
+}
+
+func (b *OCRBatcher) submitQueued(ctx context.Context, force bool) {
+	db := b.DB.WithContext(ctx)
+
+	count, oldest, err := repository.QueuedOCRStats(db)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to read OCR queue")
+		return
+	}
+	if count == 0 || (!force && count < int64(b.BatchSize) && time.Since(oldest) < b.Window) {
+		return
+	}
+
+	for ctx.Err() == nil {
+		items, err := repository.ListQueuedOCRItems(db, b.BatchSize)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to list queued OCR items")
+			return
+		}
+		if len(items) == 0 {
+			return
+		}
+		if !force && len(items) < b.BatchSize && time.Since(items[0].CreatedAt) < b.Window {
+			return
+		}
+
+		// Batch usage is only known at collection, so the estimate is held
+		// against the budget until then. Paused images stay queued until the
+		// budget allows them again.
+		if !costAllowed(b.Usage, models.UsageStageVision, estimateOCRBatchCost(b.Model, len(items))) {
+			return
+		}
+
+		if err := b.submit(ctx, items); err != nil {
+			logger.Error().Err(err).Int("items", len(items)).Msg("failed to submit OCR batch, will retry")
+			return
+		}
+	}
+}
+
+func (b *OCRBatcher) submit(ctx context.Context, items []schema.OCRBatchItem) error {
+	requests := make([]anthropic.MessageBatchNewParamsRequest, 0, len(items))
+	for i := range items {
+		items[i].CustomID = fmt.Sprintf("img-%d", items[i].ID)
+		requests = append(requests, ocrBatchRequest(b.Model, models.OCRRequest{
+			CustomID:  items[i].CustomID,
+			MediaType: items[i].MediaType,
+			Data:      items[i].Data,
+		}))
+	}
+
+	// Claimed first so no other submitter sends the same items
+	claimed, err := repository.ClaimOCRItems(b.DB.WithContext(ctx), items)
+	if err != nil {
+		return fmt.Errorf("claim items: %w", err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	// The writes below happen even if ctx was cancelled meanwhile
+	db := b.DB.WithContext(context.WithoutCancel(ctx))
+
+	messageBatch, err := b.Client.Messages.Batches.New(ctx, anthropic.MessageBatchNewParams{
+		Requests: requests,
+	})
+	if err != nil {
+		if rerr := repository.ReleaseOCRItems(db, items); rerr != nil {
+			logger.Error().Err(rerr).Int("items", len(items)).Msg("failed to requeue OCR items")
+		}
+		return err
+	}
+
+	if err := repository.SaveSubmittedOCRBatch(db, messageBatch.ID, items); err != nil {
+		// The batch still runs but cannot be collected; its items stay
+		// "submitting" until the next start puts them back in the queue
+		logger.Error().Err(err).Str("batch_id", messageBatch.ID).Int("items", len(items)).Msg("submitted OCR batch could not be recorded")
+		return fmt.Errorf("record batch %s: %w", messageBatch.ID, err)
+	}
+
+	reserveUsage(b.Usage, messageBatch.ID, estimateOCRBatchCost(b.Model, len(items)))
+
+	logger.Info().Str("batch_id", messageBatch.ID).Int("request_count", len(items)).Msg("submitted shared OCR batch")
+	return nil
+}
+
+/* ================= COLLECTION ================= */
+
+func (b *OCRBatcher) collect(ctx context.Context) {
+	batches, err := repository.ListOpenOCRBatches(b.DB.WithContext(ctx))
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to list open OCR batches")
+		return
+	}
+
+	for _, batch := range batches {
+		if ctx.Err() != nil {
+			return
+		}
+
+		status, err := b.Client.Messages.Batches.Get(ctx, batch.BatchID)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Warn().Err(err).Str("batch_id", batch.BatchID).Msg("error polling OCR batch")
+			}
+			continue
+		}
+
+		logger.Debug().
+			Str("batch_id", batch.BatchID).
+			Str("status", string(status.ProcessingStatus)).
+			Int64("succeeded", status.RequestCounts.Succeeded).
+			Int64("processing", status.RequestCounts.Processing).
+			Msg("OCR batch progress")
+
+		if status.ProcessingStatus != anthropic.MessageBatchProcessingStatusEnded {
+			continue
+		}
+
+		if err := b.collectBatch(ctx, batch); err != nil {
+			logger.Error().Err(err).Str("batch_id", batch.BatchID).Msg("failed to collect OCR batch")
+			if time.Since(batch.CreatedAt) > ocrBatchResultsExpiry {
+				repository.FinishOCRBatch(b.DB.WithContext(ctx), batch.ID, models.OCRStatusFailed, err.Error())
+				releaseUsage(b.Usage, batch.BatchID)
+			}
+		}
+	}
+}
+
+// collectBatch downloads a finished batch, caches each image's names and
+// records them as testimonials of the company the image was found on.
+func (b *OCRBatcher) collectBatch(ctx context.Context, batch schema.OCRBatch) error {
+	db := b.DB.WithContext(ctx)
+
+	items, err := repository.ListOCRBatchItems(db, batch.BatchID)
+	if err != nil {
+		return err
+	}
+
+	byCustomID := make(map[string]schema.OCRBatchItem, len(items))
+	urlMap := make(map[string]string, len(items))
+	for _, item := range items {
+		byCustomID[item.CustomID] = item
+		urlMap[item.CustomID] = item.ImageURL
+	}
+
+	results, err := getResults(ctx, batch.BatchID, urlMap)
+	if err != nil {
+		return err
+	}
+
+	namesByCompany := make(map[uint][]string)
+	answered := make(map[string]bool, len(results))
+	for _, result := range results {
+		item, ok := byCustomID[result.CustomID]
+		if !ok {
+			continue
+		}
+		answered[result.CustomID] = true
+
+		if result.Error != nil {
+			logger.Warn().Str("url", result.ImageURL).Err(result.Error).Msg("OCR failed for image")
+			repository.CompleteOCRBatchItem(db, item.ID, nil, result.Error.Error())
+			continue
+		}
+
+		// Keyed by result so collecting the batch again after a failure
+		// does not bill the image twice
+		recordUsage(b.Usage, models.LLMUsage{
+			RequestID:     batch.BatchID + "/" + result.CustomID,
+			Stage:         models.UsageStageVision,
+			SeedCompanyID: item.SeedCompanyID,
+			Model:         b.Model,
+			Batch:         true,
+			Usage:         result.Usage,
+		})
+
+		accepted := acceptedNames(result.Names)
+		cacheImageNames(db, &ocrImage{
+			URL:            item.ImageURL,
+			ContentHash:    item.ContentHash,
+			PerceptualHash: uint64(item.PerceptualHash),
+		}, accepted)
+		repository.CompleteOCRBatchItem(db, item.ID, accepted, "")
+
+		namesByCompany[item.SeedCompanyID] = append(namesByCompany[item.SeedCompanyID], accepted...)
+	}
+
+	for customID, item := range byCustomID {
+		if !answered[customID] {
+			repository.CompleteOCRBatchItem(db, item.ID, nil, "missing from batch results")
+		}
+	}
+
+	// The recorded usage replaces the estimate held since submission
+	releaseUsage(b.Usage, batch.BatchID)
+
+	for seedCompanyID, names := range namesByCompany {
+		b.recordNames(ctx, seedCompanyID, names)
+	}
+
+	logger.Info().Str("batch_id", batch.BatchID).Int("results", len(results)).Int("companies", len(namesByCompany)).Msg("collected OCR batch")
+	return repository.FinishOCRBatch(db, batch.ID, models.OCRStatusCollected, "")
+}
+
+func (b *OCRBatcher) recordNames(ctx context.Context, seedCompanyID uint, names []string) {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, name := range names {
+		if key := strings.ToLower(name); !seen[key] {
+			seen[key] = true
+			unique = append(unique, name)
+		}
+	}
+	if len(unique) == 0 {
+		return
+	}
+
+	if err := repository.BulkUpsertTestimonials(b.DB.WithContext(ctx), seedCompanyID, unique); err != nil {
+		logger.Error().Err(err).Uint("seed_company_id", seedCompanyID).Msg("error upserting testimonial names")
+		return
+	}
+
+	if b.Names == nil {
+		return
+	}
+	for _, name := range unique {
+		select {
+		case b.Names.NamesChan <- name:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
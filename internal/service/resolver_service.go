@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"golang.org/x/net/html"
+
+	"github.com/chandhuDev/JobLoop/internal/interfaces"
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	"github.com/chandhuDev/JobLoop/internal/models"
+)
+
+/* ================= CONFIG ================= */
+
+var (
+	guessTLDs = []string{".com", ".io", ".ai", ".co"}
+
+	// Hosts that show up in search results for a company without being its site
+	directoryHosts = []string{
+		"linkedin.com", "crunchbase.com", "wikipedia.org", "ycombinator.com", "twitter.com", "x.com",
+		"facebook.com", "instagram.com", "youtube.com", "github.com", "glassdoor.com", "indeed.com",
+		"bloomberg.com", "pitchbook.com", "angel.co", "wellfound.com", "g2.com", "capterra.com",
+		"medium.com",
+	}
+
+	parkedPhrases = []string{
+		"domain is for sale", "domain for sale", "buy this domain", "domain may be for sale",
+		"parked free", "parked domain", "this domain is parked",
+	}
+
+	// Confidence given to a candidate by the strongest signal naming the company
+	siteNameConfidence = 0.95
+	titleConfidence    = 0.85
+	logoConfidence     = 0.75
+	domainMatchBonus   = 0.05
+
+	maxSearchCandidates = 5
+	verifyTimeout       = 10 * time.Second
+	verifyMaxBody       = int64(2 << 20)
+)
+
+/* ================= CHAIN ================= */
+
+// NewResolverChain builds the resolvers named in RESOLVER_BACKENDS, in order.
+// The default tries cheap domain guesses first, then the search engine when
+// one is configured, then Claude web search.
+func NewResolverChain(client *anthropic.Client, engine interfaces.SearchEngine, usage interfaces.UsageRecorder) []interfaces.URLResolver {
+	backends := os.Getenv("RESOLVER_BACKENDS")
+	if backends == "" {
+		backends = "guess,search,claude"
+	}
+
+	var chain []interfaces.URLResolver
+	for _, name := range strings.Split(backends, ",") {
+		switch strings.TrimSpace(name) {
+		case "guess":
+			chain = append(chain, &DomainGuessResolver{})
+		case "search":
+			if engine != nil {
+				chain = append(chain, &SearchEngineResolver{Engine: engine})
+			}
+		case "claude":
+			if client != nil {
+				chain = append(chain, &ClaudeSearchResolver{Client: client, Usage: usage})
+			}
+		case "":
+		default:
+			logger.Warn().Str("backend", name).Msg("unknown resolver backend, ignoring")
+		}
+	}
+	return chain
+}
+
+/* ================= DOMAIN GUESSING ================= */
+
+type DomainGuessResolver struct{}
+
+func (d *DomainGuessResolver) Name() string {
+	return "guess"
+}
+
+// Resolve returns name.com/.io/.ai/.co for the squashed company name, then
+// for its hyphenated form.
+func (d *DomainGuessResolver) Resolve(ctx context.Context, companyName string) ([]string, error) {
+	slugs := mergeKeywords([]string{normalizeCompanyName(companyName)}, companySlugs(companyName, ""))
+
+	var candidates []string
+	for _, slug := range slugs {
+		if slug == "" || strings.IndexFunc(slug, func(r rune) bool { return r > unicode.MaxASCII }) != -1 {
+			continue
+		}
+		for _, tld := range guessTLDs {
+			candidates = append(candidates, "https://"+slug+tld)
+		}
+	}
+	return candidates, nil
+}
+
+/* ================= SEARCH ENGINE ================= */
+
+type SearchEngineResolver struct {
+	Engine interfaces.SearchEngine
+}
+
+func (s *SearchEngineResolver) Name() string {
+	return "search"
+}
+
+// Resolve reduces the top search results to their site roots, skipping
+// directories and social networks that merely describe the company.
+func (s *SearchEngineResolver) Resolve(ctx context.Context, companyName string) ([]string, error) {
+	results, err := s.Engine.Search(ctx, fmt.Sprintf("%s official website", companyName))
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, result := range results {
+		parsed, err := url.Parse(result)
+		if err != nil || parsed.Hostname() == "" {
+			continue
+		}
+
+		host := strings.ToLower(parsed.Hostname())
+		if isDirectoryHost(host) || seen[host] {
+			continue
+		}
+		seen[host] = true
+
+		candidates = append(candidates, "https://"+host)
+		if len(candidates) >= maxSearchCandidates {
+			break
+		}
+	}
+	return candidates, nil
+}
+
+func isDirectoryHost(host string) bool {
+	domain := registrableDomain(host)
+	for _, d := range directoryHosts {
+		if domain == d {
+			return true
+		}
+	}
+	return false
+}
+
+// FakeSearchEngine answers queries from a fixed table keyed by lowercase
+// company name, so the resolver chain can run offline.
+type FakeSearchEngine struct {
+	Results map[string][]string
+}
+
+func (f *FakeSearchEngine) Search(ctx context.Context, query string) ([]string, error) {
+	lower := strings.ToLower(query)
+	for name, results := range f.Results {
+		if strings.Contains(lower, strings.ToLower(name)) {
+			return results, nil
+		}
+	}
+	return nil, nil
+}
+
+// LoadFakeSearchEngine reads a JSON object of company name to result URLs.
+// It returns nil when path is empty.
+func LoadFakeSearchEngine(path string) (interfaces.SearchEngine, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var results map[string][]string
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("invalid search fixtures %s: %w", path, err)
+	}
+	return &FakeSearchEngine{Results: results}, nil
+}
+
+/* ================= CLAUDE ================= */
+
+type ClaudeSearchResolver struct {
+	Client *anthropic.Client
+	Usage  interfaces.UsageRecorder
+}
+
+func (c *ClaudeSearchResolver) Name() string {
+	return "claude"
+}
+
+func (c *ClaudeSearchResolver) Resolve(ctx context.Context, companyName string) ([]string, error) {
+	if !usageAllowed(c.Usage, models.UsageStageResolve) {
+		return nil, ErrBudgetExhausted
+	}
+
+	resp, err := c.Client.Messages.New(ctx, anthropic.MessageNewParams{
+		Model:     anthropic.Model(envString("RESOLVER_MODEL", string(anthropic.ModelClaudeSonnet4_5_20250929))),
+		MaxTokens: 512,
+		Tools: []anthropic.ToolUnionParam{
+			{
+				OfTool: &anthropic.ToolParam{
+					Type: "web_search_20250305",
+					Name: "web_search",
+				},
+			},
+		},
+		Messages: []anthropic.MessageParam{
+			{
+				Role: anthropic.MessageParamRoleUser,
+				Content: []anthropic.ContentBlockParamUnion{
+					{
+						OfText: &anthropic.TextBlockParam{
+							Type: "text",
+							Text: fmt.Sprintf(`Find the official website URL for the company "%s". 
+Return ONLY the main domain URL (e.g., https://example.com).
+If not found, return "NOT_FOUND".`, companyName),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	recordUsage(c.Usage, models.LLMUsage{
+		Stage:       models.UsageStageResolve,
+		CompanyName: companyName,
+		Model:       string(resp.Model),
+		Usage:       messageUsage(resp.Usage),
+	})
+
+	var candidates []string
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			if url := extractURLFromText(block.Text); url != "" {
+				candidates = append(candidates, url)
+			}
+		}
+	}
+
+	return candidates, nil
+}
+
+/* ================= VERIFICATION ================= */
+
+type SiteVerifier struct {
+	Client *http.Client
+}
+
+type siteSignals struct {
+	Title     string
+	SiteName  string
+	LogoTexts []string
+}
+
+func NewSiteVerifier() *SiteVerifier {
+	return &SiteVerifier{
+		Client: &http.Client{Timeout: verifyTimeout},
+	}
+}
+
+// Verify fetches the candidate and accepts it when the page title,
+// og:site_name or a logo's alt text names the company. It returns the site
+// root after redirects so the stored URL is the one the company serves.
+func (v *SiteVerifier) Verify(ctx context.Context, companyName string, candidate string) (string, float64, bool) {
+	req, err := http.NewRequestWithContext(ctx, "GET", candidate, nil)
+	if err != nil {
+		return "", 0, false
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := v.Client.Do(req)
+	if err != nil {
+		logger.Debug().Err(err).Str("candidate", candidate).Msg("candidate unreachable")
+		return "", 0, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		logger.Debug().Int("status", resp.StatusCode).Str("candidate", candidate).Msg("candidate returned error status")
+		return "", 0, false
+	}
+
+	doc, err := html.Parse(io.LimitReader(resp.Body, verifyMaxBody))
+	if err != nil {
+		return "", 0, false
+	}
+
+	signals := collectSiteSignals(doc)
+	if containsAny(strings.ToLower(signals.Title), parkedPhrases) {
+		logger.Debug().Str("candidate", candidate).Str("title", signals.Title).Msg("candidate looks parked")
+		return "", 0, false
+	}
+
+	confidence := 0.0
+	var matched string
+	for _, signal := range []struct {
+		text   []string
+		weight float64
+	}{
+		{[]string{signals.SiteName}, siteNameConfidence},
+		{[]string{signals.Title}, titleConfidence},
+		{signals.LogoTexts, logoConfidence},
+	} {
+		for _, text := range signal.text {
+			if nameMatchesSignal(companyName, text) {
+				confidence, matched = signal.weight, text
+				break
+			}
+		}
+		if confidence > 0 {
+			break
+		}
+	}
+
+	if confidence > 0 {
+		final := resp.Request.URL
+		domain := registrableDomain(final.Hostname())
+		if idx := strings.Index(domain, "."); idx > 0 && domain[:idx] == normalizeCompanyName(companyName) {
+			confidence = min(1, confidence+domainMatchBonus)
+		}
+
+		logger.Debug().Str("candidate", candidate).Str("signal", matched).Float64("confidence", confidence).Msg("candidate verified")
+		return final.Scheme + "://" + final.Host, confidence, true
+	}
+
+	logger.Debug().
+		Str("company", companyName).
+		Str("candidate", candidate).
+		Str("title", signals.Title).
+		Str("site_name", signals.SiteName).
+		Msg("candidate does not name the company")
+	return "", 0, false
+}
+
+func collectSiteSignals(doc *html.Node) siteSignals {
+	var signals siteSignals
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			attrs := make(map[string]string, len(n.Attr))
+			for _, a := range n.Attr {
+				attrs[strings.ToLower(a.Key)] = a.Val
+			}
+
+			switch n.Data {
+			case "title":
+				if signals.Title == "" && n.FirstChild != nil {
+					signals.Title = strings.TrimSpace(n.FirstChild.Data)
+				}
+			case "meta":
+				key := strings.ToLower(attrs["property"] + attrs["name"])
+				if key == "og:site_name" || key == "application-name" {
+					if signals.SiteName == "" {
+						signals.SiteName = strings.TrimSpace(attrs["content"])
+					}
+				}
+			case "img":
+				hint := strings.ToLower(attrs["class"] + " " + attrs["id"] + " " + attrs["src"] + " " + attrs["alt"])
+				if strings.Contains(hint, "logo") && attrs["alt"] != "" {
+					signals.LogoTexts = append(signals.LogoTexts, attrs["alt"])
+				}
+			}
+
+			if label := attrs["aria-label"]; label != "" && strings.Contains(strings.ToLower(attrs["class"]+" "+attrs["id"]+" "+label), "logo") {
+				signals.LogoTexts = append(signals.LogoTexts, label)
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return signals
+}
+
+// nameMatchesSignal compares the company name with a page signal after
+// dropping legal suffixes. Longer names may appear anywhere in the squashed
+// signal ("Acme Robotics | Home"); short ones must match a whole word so
+// "Ro" does not match "Robots".
+func nameMatchesSignal(companyName string, signal string) bool {
+	name := normalizeCompanyName(companyName)
+	if name == "" || strings.TrimSpace(signal) == "" {
+		return false
+	}
+
+	if len([]rune(name)) >= 4 {
+		return strings.Contains(normalizeCompanyName(signal), name)
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(signal), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if w == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chandhuDev/JobLoop/internal/interfaces"
+)
+
+func TestExtractURLFromText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"full URL", "The website is https://acme.com/about.", "https://acme.com/about"},
+		{"URL in brackets", "Website: (https://www.acme.io)", "https://www.acme.io"},
+		{"URL wins over bare domain", "acme.com, or rather https://acme.ai", "https://acme.ai"},
+		{"bare domain", "I think it is acme.io", "https://acme.io"},
+		{"markdown bold domain", "**acme.co**", "https://acme.co"},
+		{"no URL", "I could not find a website for this company.", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractURLFromText(tt.text); got != tt.want {
+				t.Errorf("extractURLFromText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameMatchesSignal(t *testing.T) {
+	tests := []struct {
+		name    string
+		company string
+		signal  string
+		want    bool
+	}{
+		{"exact title", "Acme Robotics", "Acme Robotics", true},
+		{"title with suffix", "Acme Robotics", "Acme Robotics | Home", true},
+		{"legal suffix dropped", "Acme Inc.", "ACME - Build faster", true},
+		{"different company", "Acme Robotics", "Globex Corporation", false},
+		{"short name whole word", "Ro", "Ro | Online health clinic", true},
+		{"short name inside word", "Ro", "Robots for everyone", false},
+		{"empty signal", "Acme", "  ", false},
+		{"name of suffixes only", "Inc", "Inc magazine", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nameMatchesSignal(tt.company, tt.signal); got != tt.want {
+				t.Errorf("nameMatchesSignal(%q, %q) = %v, want %v", tt.company, tt.signal, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompanySlugs(t *testing.T) {
+	tests := []struct {
+		name    string
+		company string
+		domain  string
+		want    []string
+	}{
+		{"single word", "Stripe", "", []string{"stripe"}},
+		{"several words", "Acme Robotics", "", []string{"acmerobotics", "acme-robotics"}},
+		{"punctuation", "Rock & Roll, Inc.", "", []string{"rockrollinc", "rock-roll-inc"}},
+		{"with domain", "Acme Robotics", "acmebots.com", []string{"acmerobotics", "acme-robotics", "acmebots"}},
+		{"domain repeats slug", "Stripe", "stripe.com", []string{"stripe"}},
+		{"empty", "", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := companySlugs(tt.company, tt.domain); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("companySlugs(%q, %q) = %q, want %q", tt.company, tt.domain, got, tt.want)
+			}
+		})
+	}
+}
+
+/* ================= CHAIN ================= */
+
+type stubResolver struct {
+	name       string
+	candidates []string
+	err        error
+	calls      int
+}
+
+func (s *stubResolver) Name() string {
+	return s.name
+}
+
+func (s *stubResolver) Resolve(ctx context.Context, companyName string) ([]string, error) {
+	s.calls++
+	return s.candidates, s.err
+}
+
+// hostVerifier accepts candidates whose host is in the set, standing in for
+// the page fetch of the real verifier.
+type hostVerifier map[string]bool
+
+func (h hostVerifier) Verify(ctx context.Context, companyName string, candidate string) (string, float64, bool) {
+	host := strings.TrimPrefix(strings.TrimPrefix(candidate, "https://"), "http://")
+	if !h[strings.TrimSuffix(host, "/")] {
+		return "", 0, false
+	}
+	return candidate, 0.9, true
+}
+
+func TestSearchKeywordResolverChain(t *testing.T) {
+	engine := &FakeSearchEngine{Results: map[string][]string{
+		"acme robotics": {
+			"https://www.linkedin.com/company/acme-robotics",
+			"https://acmebots.com/about",
+			"https://acmebots.com/careers",
+			"https://news.example.com/acme",
+		},
+	}}
+
+	tests := []struct {
+		name      string
+		company   string
+		resolvers func() []interfaces.URLResolver
+		verifier  interfaces.URLVerifier
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:    "search result after directory hosts",
+			company: "Acme Robotics",
+			resolvers: func() []interfaces.URLResolver {
+				return []interfaces.URLResolver{&SearchEngineResolver{Engine: engine}}
+			},
+			verifier: hostVerifier{"acmebots.com": true},
+			want:     "https://acmebots.com",
+		},
+		{
+			name:    "falls through to the next resolver",
+			company: "Acme Robotics",
+			resolvers: func() []interfaces.URLResolver {
+				return []interfaces.URLResolver{
+					&stubResolver{name: "guess", candidates: []string{"https://acmerobotics.com"}},
+					&SearchEngineResolver{Engine: engine},
+				}
+			},
+			verifier: hostVerifier{"acmebots.com": true},
+			want:     "https://acmebots.com",
+		},
+		{
+			name:    "failing resolver is skipped",
+			company: "Acme Robotics",
+			resolvers: func() []interfaces.URLResolver {
+				return []interfaces.URLResolver{
+					&stubResolver{name: "broken", err: context.DeadlineExceeded},
+					&SearchEngineResolver{Engine: engine},
+				}
+			},
+			verifier: hostVerifier{"acmebots.com": true},
+			want:     "https://acmebots.com",
+		},
+		{
+			name:    "unverified candidate accepted without verifier",
+			company: "Globex",
+			resolvers: func() []interfaces.URLResolver {
+				return []interfaces.URLResolver{&stubResolver{name: "guess", candidates: []string{"https://globex.io"}}}
+			},
+			want: "https://globex.io",
+		},
+		{
+			name:    "nothing verifies",
+			company: "Unknown Startup",
+			resolvers: func() []interfaces.URLResolver {
+				return []interfaces.URLResolver{&SearchEngineResolver{Engine: engine}}
+			},
+			verifier: hostVerifier{},
+			wantErr:  true,
+		},
+		{
+			name:    "name too long",
+			company: "An Extremely Long Company Name That Is Not Real",
+			resolvers: func() []interfaces.URLResolver {
+				return []interfaces.URLResolver{&SearchEngineResolver{Engine: engine}}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SearchService{Resolvers: tt.resolvers(), Verifier: tt.verifier}
+			got, err := s.SearchKeyword(context.Background(), tt.company, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SearchKeyword(%q) error = %v, wantErr %v", tt.company, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SearchKeyword(%q) = %q, want %q", tt.company, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchKeywordTriesEachCandidateOnce(t *testing.T) {
+	first := &stubResolver{name: "first", candidates: []string{"https://acme.com"}}
+	second := &stubResolver{name: "second", candidates: []string{"https://ACME.com/", "https://acme.io"}}
+
+	verifier := &countingVerifier{accept: "https://acme.io"}
+	s := &SearchService{Resolvers: []interfaces.URLResolver{first, second}, Verifier: verifier}
+
+	got, err := s.SearchKeyword(context.Background(), "Acme", 0)
+	if err != nil {
+		t.Fatalf("SearchKeyword: %v", err)
+	}
+	if got != "https://acme.io" {
+		t.Errorf("SearchKeyword = %q, want %q", got, "https://acme.io")
+	}
+	if verifier.calls != 2 {
+		t.Errorf("verifier called %d times, want 2", verifier.calls)
+	}
+}
+
+type countingVerifier struct {
+	accept string
+	calls  int
+}
+
+func (c *countingVerifier) Verify(ctx context.Context, companyName string, candidate string) (string, float64, bool) {
+	c.calls++
+	return candidate, 0.9, candidate == c.accept
+}
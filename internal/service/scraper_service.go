@@ -1,6 +1,9 @@
 package service
 
 import (
+	"context"
+	"time"
+
 	"github.com/anthropics/anthropic-sdk-go"
 	interfaces "github.com/chandhuDev/JobLoop/internal/interfaces"
 	"github.com/chandhuDev/JobLoop/internal/models"
@@ -11,6 +14,9 @@ func SetUpScraperClient(browser interfaces.BrowserClient,
 	search interfaces.SearchClient,
 	dbClient interfaces.DatabaseClient,
 	namesChannel *models.NamesClient,
+	artifacts interfaces.ArtifactClient,
+	runID string,
+	usage interfaces.UsageRecorder,
 ) *interfaces.ScraperClient {
 	return &interfaces.ScraperClient{
 		Browser:         browser,
@@ -18,5 +24,14 @@ func SetUpScraperClient(browser interfaces.BrowserClient,
 		Search:          search,
 		DbClient:        dbClient,
 		NamesChanClient: namesChannel,
+		Artifacts:       artifacts,
+		RunID:           runID,
+		Usage:           usage,
 	}
 }
+
+// withCompanyDeadline bounds the time one stage may spend on one company
+// (COMPANY_TIME_BUDGET, default 5m) so a slow site cannot hold a worker.
+func withCompanyDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, envDuration("COMPANY_TIME_BUDGET", 5*time.Minute))
+}
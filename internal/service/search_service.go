@@ -2,42 +2,39 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
+	"github.com/chandhuDev/JobLoop/internal/interfaces"
+	"github.com/chandhuDev/JobLoop/internal/logger"
 	"github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/repository"
+	"github.com/chandhuDev/JobLoop/internal/schema"
+	"gorm.io/gorm"
 )
 
 type SearchService struct {
-	Client *models.Search
+	Client    *models.Search
+	Resolvers []interfaces.URLResolver
+	Verifier  interfaces.URLVerifier
+	DB        *gorm.DB
 }
 
+// Confidence recorded for candidates accepted without a verifier
+const unverifiedConfidence = 0.5
+
 type SearchResult struct {
 	CompanyName string
 	URL         string
 	Error       error
 }
 
-type SearchBatchResult struct {
-	CustomID string `json:"custom_id"`
-	Result   struct {
-		Type    string `json:"type"`
-		Message struct {
-			Content []struct {
-				Type string `json:"type"`
-				Text string `json:"text"`
-			} `json:"content"`
-		} `json:"message"`
-		Error struct {
-			Type    string `json:"type"`
-			Message string `json:"message"`
-		} `json:"error"`
-	} `json:"result"`
-}
-
 func CreateSearchService() *anthropic.Client {
 	client := anthropic.NewClient(
 		option.WithAPIKey(os.Getenv("ANTHROPIC_API_KEY")),
@@ -51,71 +48,143 @@ func SetUpSearch(client *anthropic.Client) *models.Search {
 	}
 }
 
-func (s *SearchService) SearchKeyword(companyName string, workerId int) (string, error) {
+// SearchKeyword answers from the resolution cache when it can, otherwise runs
+// the resolver chain in order and caches the first candidate that passes
+// verification, or a negative result when none does.
+func (s *SearchService) SearchKeyword(ctx context.Context, companyName string, workerId int) (string, error) {
 
 	if len(companyName) > 30 {
 		return "", fmt.Errorf("company name too long")
 	}
 
-	resp, err := s.Client.Search.Messages.New(context.TODO(), anthropic.MessageNewParams{
-		Model:     anthropic.ModelClaudeSonnet4_5_20250929,
-		MaxTokens: 512,
-		Tools: []anthropic.ToolUnionParam{
-			{
-				OfTool: &anthropic.ToolParam{
-					Type: "web_search_20250305",
-					Name: "web_search",
-				},
-			},
-		},
-		Messages: []anthropic.MessageParam{
-			{
-				Role: anthropic.MessageParamRoleUser,
-				Content: []anthropic.ContentBlockParamUnion{
-					{
-						OfText: &anthropic.TextBlockParam{
-							Type: "text",
-							Text: fmt.Sprintf(`Find the official website URL for the company "%s". 
-Return ONLY the main domain URL (e.g., https://example.com).
-If not found, return "NOT_FOUND".`, companyName),
-						},
-					},
-				},
-			},
-		},
-	})
-
-	if err != nil {
-		return "", err
+	if s.DB != nil {
+		cached, err := repository.GetCompanyResolution(s.DB.WithContext(ctx), companyName)
+		if err != nil {
+			logger.Warn().Err(err).Str("company", companyName).Msg("failed to read resolution cache")
+		} else if cached != nil {
+			if cached.Negative {
+				return "", fmt.Errorf("no website found for %s (cached)", companyName)
+			}
+			logger.Info().Str("company", companyName).Str("url", cached.CompanyURL).Float64("confidence", cached.Confidence).Msg("resolved company website from cache")
+			return cached.CompanyURL, nil
+		}
 	}
 
-	for _, block := range resp.Content {
-		if block.Type == "text" {
-			url := extractURLFromText(block.Text)
-			if url != "" && url != "NOT_FOUND" {
-				return url, nil
+	tried := make(map[string]bool)
+	failed := 0
+	paused := false
+
+	for _, resolver := range s.Resolvers {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		candidates, err := resolver.Resolve(ctx, companyName)
+		if errors.Is(err, ErrBudgetExhausted) {
+			paused = true
+			continue
+		}
+		if err != nil {
+			failed++
+			logger.Warn().Err(err).Str("resolver", resolver.Name()).Str("company", companyName).Int("worker_id", workerId).Msg("resolver failed")
+			continue
+		}
+
+		for _, candidate := range candidates {
+			key := strings.TrimSuffix(strings.ToLower(candidate), "/")
+			if tried[key] {
+				continue
 			}
+			tried[key] = true
+
+			siteURL, confidence := candidate, unverifiedConfidence
+			if s.Verifier != nil {
+				var ok bool
+				if siteURL, confidence, ok = s.Verifier.Verify(ctx, companyName, candidate); !ok {
+					continue
+				}
+			}
+
+			logger.Info().Str("resolver", resolver.Name()).Str("company", companyName).Str("url", siteURL).Float64("confidence", confidence).Msg("resolved company website")
+			s.cacheResolution(ctx, companyName, siteURL, confidence, resolver.Name(), false)
+			return siteURL, nil
 		}
 	}
 
+	// Only remember a miss when every backend actually answered, not when a
+	// backend was paused by the spend limit
+	if failed == 0 && !paused && ctx.Err() == nil {
+		s.cacheResolution(ctx, companyName, "", 0, "chain", true)
+	}
+
 	return "", fmt.Errorf("no website found for %s", companyName)
 }
 
+func (s *SearchService) cacheResolution(ctx context.Context, companyName string, companyURL string, confidence float64, source string, negative bool) {
+	if s.DB == nil {
+		return
+	}
+
+	now := time.Now()
+	ttl := envDuration("RESOLUTION_TTL", 90*24*time.Hour)
+	if negative {
+		ttl = envDuration("RESOLUTION_NEGATIVE_TTL", 7*24*time.Hour)
+	}
+	expires := now.Add(ttl)
+
+	err := repository.SaveCompanyResolution(s.DB.WithContext(ctx), schema.CompanyResolution{
+		CompanyName: companyName,
+		CompanyURL:  companyURL,
+		Confidence:  confidence,
+		Source:      source,
+		Negative:    negative,
+		ResolvedAt:  now,
+		ExpiresAt:   &expires,
+	})
+	if err != nil {
+		logger.Warn().Err(err).Str("company", companyName).Msg("failed to cache company resolution")
+	}
+}
+
+func envDuration(env string, fallback time.Duration) time.Duration {
+	if ttl, err := time.ParseDuration(os.Getenv(env)); err == nil && ttl > 0 {
+		return ttl
+	}
+	return fallback
+}
+
+func envInt(env string, fallback int) int {
+	if n, err := strconv.Atoi(os.Getenv(env)); err == nil && n > 0 {
+		return n
+	}
+	return fallback
+}
+
+func envString(env string, fallback string) string {
+	if value := strings.TrimSpace(os.Getenv(env)); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// extractURLFromText returns the first URL or bare domain in a free-text
+// reply, or "" when the reply contains neither.
 func extractURLFromText(text string) string {
 	text = strings.TrimSpace(text)
 
 	words := strings.Fields(text)
 	for _, word := range words {
-		word = strings.TrimRight(word, ".,;:!?")
+		word = strings.Trim(word, "()[]<>\"'`*.,;:!?")
 		if strings.HasPrefix(word, "http://") || strings.HasPrefix(word, "https://") {
 			return word
 		}
 	}
 
 	for _, word := range words {
-		word = strings.TrimRight(word, ".,;:!?")
+		word = strings.Trim(word, "()[]<>\"'`*.,;:!?")
 		if strings.Contains(word, ".com") || strings.Contains(word, ".io") ||
-			strings.Contains(word, ".org") || strings.Contains(word, ".net") {
+			strings.Contains(word, ".org") || strings.Contains(word, ".net") ||
+			strings.Contains(word, ".ai") || strings.Contains(word, ".co") {
 			if !strings.HasPrefix(word, "http") {
 				return "https://" + word
 			}
@@ -123,5 +192,5 @@ func extractURLFromText(text string) string {
 		}
 	}
 
-	return text
+	return ""
 }
@@ -12,6 +12,7 @@ import (
 	"time"
 	"strconv"
 
+	"github.com/chandhuDev/JobLoop/internal/config"
 	"github.com/chandhuDev/JobLoop/internal/interfaces"
 	"github.com/chandhuDev/JobLoop/internal/logger"
 	"github.com/chandhuDev/JobLoop/internal/models"
@@ -32,18 +33,20 @@ type CompanyData struct {
 func NewSeedCompanyScraper(companyConfig models.SeedCompany) *models.SeedCompany {
 	return &models.SeedCompany{
 		Name:     companyConfig.Name,
+		Kind:     companyConfig.Kind,
 		URL:      companyConfig.URL,
 		Selector: companyConfig.Selector,
 		WaitTime: companyConfig.WaitTime,
 	}
 }
 
-func NewSeedCompanyArray(firstSeedCompany models.SeedCompany, secondSeedCompany models.SeedCompany) *models.SeedCompanyArray {
+func NewSeedCompanyArray(bufferSize int, seedCompanies ...models.SeedCompany) *models.SeedCompanyArray {
 	return &models.SeedCompanyArray{
-		Companies:  []models.SeedCompany{firstSeedCompany, secondSeedCompany},
+		Companies:  seedCompanies,
 		PWg:        &sync.WaitGroup{},
 		YCWg:       &sync.WaitGroup{},
-		ResultChan: make(chan models.SeedCompanyResult, 500),
+		JobWg:      &sync.WaitGroup{},
+		ResultChan: make(chan models.SeedCompanyResult, bufferSize),
 	}
 }
 
@@ -51,14 +54,14 @@ func (s *SeedCompanyService) SeedCompanyConfigs(ctx context.Context, scraper *in
 	logger.Info().Msg("seed company scraper started")
 	defer close(s.SeedCompany.ResultChan)
 
+	s.resumePendingWork(ctx, scraper)
+
 	for i := 0; i < len(s.SeedCompany.Companies); i++ {
-		select {
-		case <-ctx.Done():
-			logger.Info().Msg("SeedCompany stopping (context cancelled)")
-			return
-		default:
+		if ctx.Err() != nil || draining(scraper) {
+			logger.Info().Msg("SeedCompany stopping (no longer taking seeds)")
+			break
 		}
-		if s.SeedCompany.Companies[i].Name == "Peer list" {
+		if s.SeedCompany.Companies[i].Kind == config.SeedKindPeerList {
 			// s.SeedCompany.PWg.Add(1)
 			// go func(sp models.SeedCompany) {
 			// 	defer s.SeedCompany.PWg.Done()
@@ -76,13 +79,14 @@ func (s *SeedCompanyService) SeedCompanyConfigs(ctx context.Context, scraper *in
 
 	s.SeedCompany.PWg.Wait()
 	s.SeedCompany.YCWg.Wait()
+	s.SeedCompany.JobWg.Wait()
 	logger.Info().Msg("closing seedcompany waitgroups and result channel")
 }
 
 func (s *SeedCompanyService) GetSeedCompaniesFromPeerList(scraper *interfaces.ScraperClient, sp *models.SeedCompany, ctx context.Context) {
 	logger.Info().Msg("worker started for peerlist")
 
-	page, err := scraper.Browser.RunInNewTab()
+	page, err := scraper.Browser.RunInNewTab(ctx)
 	if err != nil {
 		logger.Error().Err(err).Int("worker_id", -1).Msg("error creating page for peerlist")
 		return
@@ -119,18 +123,26 @@ func (s *SeedCompanyService) GetSeedCompaniesFromPeerList(scraper *interfaces.Sc
 				logger.Error().Err(err).Msg("error getting text")
 				continue
 			}
-			scraper.NamesChanClient.NamesChan <- LastWord(urlText)
+			select {
+			case scraper.NamesChanClient.NamesChan <- LastWord(urlText):
+			case <-ctx.Done():
+				return
+			}
 		}
 	}()
 
-	s.UploadSeedCompanyToChannel(scraper)
+	s.UploadSeedCompanyToChannel(ctx, scraper)
 }
 
 func (s *SeedCompanyService) GetSeedCompaniesFromYCombinator(ctx context.Context, scraper *interfaces.ScraperClient, yc *models.SeedCompany) {
 	logger.Info().Msg("worker started for ycombinator")
 
+	// The listing is only read while the run takes new seeds
+	intake, stopIntake := intakeContext(ctx, scraper)
+	defer stopIntake()
+
 	logger.Info().Msg("START processing for ycombinator")
-	page, err := scraper.Browser.RunInNewTab()
+	page, err := scraper.Browser.RunInNewTab(intake)
 	if err != nil {
 		logger.Error().Err(err).Msg("error creating page for ycombinator")
 		return
@@ -154,7 +166,7 @@ func (s *SeedCompanyService) GetSeedCompaniesFromYCombinator(ctx context.Context
 	logger.Info().Int("total_companies", len(companyData)).Msg("Finished scraping all companies")
 
 	// Step 2: Visit each YC profile to get actual company URLs
-	companyData, err = getCompanyUrls(page, companyData)
+	companyData, err = getCompanyUrls(intake, page, companyData)
 	if err != nil {
 		logger.Error().Err(err).Msg("error at getCompanyUrls")
 		return
@@ -162,6 +174,11 @@ func (s *SeedCompanyService) GetSeedCompaniesFromYCombinator(ctx context.Context
 
 	// Step 3: Process each company
 	for i, company := range companyData {
+		if intake.Err() != nil {
+			logger.Info().Msg("YCombinator worker stopping (no longer taking seeds)")
+			return
+		}
+
 		logger.Info().Int("index", i+1).Int("total", len(companyData)).Str("company", company.Name).Str("url", company.ActualURL).Msg("Processing company")
 
 		// Skip if no actual URL found
@@ -170,33 +187,63 @@ func (s *SeedCompanyService) GetSeedCompaniesFromYCombinator(ctx context.Context
 			continue
 		}
 
-		scrId := CreateSeedCompanyRepo(company.Name, company.ActualURL, -1, *scraper)
+		scrId, companyURL := CreateSeedCompanyRepo(ctx, company.Name, company.ActualURL, -1, *scraper)
+		result := models.SeedCompanyResult{
+			CompanyName:   company.Name,
+			CompanyURL:    companyURL,
+			SeedCompanyId: scrId,
+		}
 
 		done := make(chan struct{})
-		go func(companyUrl string, seedId uint, companyName string) {
-			<-done
-			scrapedJobResults, err := getJobResults(scraper.Browser, companyUrl)
+		s.startJobScrape(ctx, scraper, result, done)
 
-			if err != nil {
-				logger.Error().Str("company", companyName).Str("url", companyUrl).Err(err).Msg("FAILED to scrape jobs (likely no careers page)")
-				return
-			}
-			repository.UpsertJob(scraper.DbClient.GetDB(), seedId, scrapedJobResults)
-
-			logger.Info().Str("company", companyName).Int("job_count", len(scrapedJobResults)).Msg("SUCCESS: Upserted jobs")
+		select {
+		case s.SeedCompany.ResultChan <- result:
+		case <-ctx.Done():
+			checkpointCompany(scraper, models.PendingStageTestimonials, result)
+			return
+		}
 
-		}(company.ActualURL, scrId, company.Name)
+		close(done)
 
-		s.SeedCompany.ResultChan <- models.SeedCompanyResult{
-			CompanyName:   company.Name,
-			CompanyURL:    company.ActualURL,
-			SeedCompanyId: scrId,
+		if sleepContext(intake, envDuration("SEED_DELAY", 3*time.Second)) != nil {
+			return
 		}
+	}
+}
 
-		done <- struct{}{}
+// startJobScrape scrapes the company's jobs once ready is closed, i.e. after
+// the company was handed to the testimonial stage. Jobs the run could not
+// scrape before stopping are checkpointed for the next run.
+func (s *SeedCompanyService) startJobScrape(ctx context.Context, scraper *interfaces.ScraperClient, company models.SeedCompanyResult, ready <-chan struct{}) {
+	s.SeedCompany.JobWg.Add(1)
+	go func() {
+		defer s.SeedCompany.JobWg.Done()
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			checkpointCompany(scraper, models.PendingStageJobs, company)
+			return
+		}
+		s.scrapeCompanyJobs(ctx, scraper, company)
+	}()
+}
 
-		time.Sleep(3 * time.Second)
+func (s *SeedCompanyService) scrapeCompanyJobs(ctx context.Context, scraper *interfaces.ScraperClient, company models.SeedCompanyResult) {
+	scrapedJobResults, err := getJobResults(ctx, scraper, company)
+	if err != nil {
+		if ctx.Err() != nil {
+			checkpointCompany(scraper, models.PendingStageJobs, company)
+			return
+		}
+		logger.Error().Str("company", company.CompanyName).Str("url", company.CompanyURL).Err(err).Msg("FAILED to scrape jobs (likely no careers page)")
+		completeCheckpoint(scraper, models.PendingStageJobs, company.CompanyURL)
+		return
 	}
+	repository.UpsertJob(scraper.DbClient.GetDB().WithContext(ctx), company.SeedCompanyId, scrapedJobResults)
+	completeCheckpoint(scraper, models.PendingStageJobs, company.CompanyURL)
+
+	logger.Info().Str("company", company.CompanyName).Int("job_count", len(scrapedJobResults)).Msg("SUCCESS: Upserted jobs")
 }
 
 func scrapeCompaniesWithScroll(page playwright.Page, selector string) []CompanyData {
@@ -347,10 +394,14 @@ func scrapeCompaniesWithScroll(page playwright.Page, selector string) []CompanyD
 	return allCompanies
 }
 
-func getCompanyUrls(page playwright.Page, data []CompanyData) ([]CompanyData, error) {
+func getCompanyUrls(ctx context.Context, page playwright.Page, data []CompanyData) ([]CompanyData, error) {
 	logger.Info().Int("total", len(data)).Msg("Starting to fetch actual company URLs")
 
 	for i := range data {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		logger.Info().Int("index", i+1).Int("total", len(data)).Str("company", data[i].Name).Str("yc_url", data[i].YCUrl).Msg("Fetching actual URL")
 
 		_, err := page.Goto(data[i].YCUrl, playwright.PageGotoOptions{
@@ -381,26 +432,43 @@ func getCompanyUrls(page playwright.Page, data []CompanyData) ([]CompanyData, er
 		logger.Info().Str("company", data[i].Name).Str("actual_url", actualURL).Msg("SCRAPED actual company URL")
 
 		// Small delay to avoid overwhelming the server
-		time.Sleep(500 * time.Millisecond)
+		if err := sleepContext(ctx, 500*time.Millisecond); err != nil {
+			return nil, err
+		}
 	}
 
 	logger.Info().Int("total_with_urls", len(data)).Msg("Finished fetching actual URLs")
 	return data, nil
 }
 
-func (s *SeedCompanyService) UploadSeedCompanyToChannel(scraper *interfaces.ScraperClient) {
+func (s *SeedCompanyService) UploadSeedCompanyToChannel(ctx context.Context, scraper *interfaces.ScraperClient) {
 	var searchWg sync.WaitGroup
 
 	// const maxCompanies = 15
 	// var processedCount atomic.Int32
 
-	workerCount := 2
+	workerCount := envInt("SEARCH_WORKERS", 2)
 	for i := 0; i < workerCount; i++ {
 		searchWg.Add(1)
 		go func(workerID int) {
 			defer searchWg.Done()
 			logger.Info().Int("id", workerID).Msg("starting goroutine for search scraper in uploadSeedCompanyToChannel func by")
-			for name := range scraper.NamesChanClient.NamesChan {
+			for {
+				var name string
+				select {
+				case <-ctx.Done():
+					logger.Info().Int("worker_id", workerID).Msg("Search worker stopping (context cancelled)")
+					return
+				case <-scraper.Draining:
+					logger.Info().Int("worker_id", workerID).Msg("Search worker stopping (no longer taking seeds)")
+					return
+				case n, ok := <-scraper.NamesChanClient.NamesChan:
+					if !ok {
+						return
+					}
+					name = n
+				}
+
 				// Check if we've reached the limit
 				// if processedCount.Load() >= maxCompanies {
 				// 	logger.Info().Int("worker", workerID).Int("processed", int(processedCount.Load())).Msg("Reached maximum company limit, stopping PeerList processing")
@@ -411,47 +479,12 @@ func (s *SeedCompanyService) UploadSeedCompanyToChannel(scraper *interfaces.Scra
 					continue
 				}
 
-				result, err := scraper.Search.SearchKeyword(
-					name, workerID,
-				)
-
-				if err != nil {
-					logger.Error().Err(err).Int("worker_id", workerID).Msg("error searching google")
-					continue
+				if !s.resolveName(ctx, scraper, name, workerID) {
+					return
 				}
 
-				if result == "" {
-					logger.Warn().Str("name", name).Msg("empty result, skipping")
-					continue
-				}
-
-				logger.Info().Str("url", result).Msg("company url result")
-
 				// Increment counter
 				// processedCount.Add(1)
-
-				scrId := CreateSeedCompanyRepo(name, result, workerID, *scraper)
-
-				done := make(chan struct{})
-				go func(id uint, url string, companyName string) {
-					<-done
-					scrapedJobResults, err := getJobResults(scraper.Browser, url)
-					if err != nil {
-						logger.Error().Str("company", companyName).Str("url", url).Err(err).Msg("FAILED to scrape jobs (likely no careers page)")
-						return
-					}
-					repository.UpsertJob(scraper.DbClient.GetDB(), id, scrapedJobResults)
-					logger.Info().Str("company", companyName).Int("job_count", len(scrapedJobResults)).Msg("SUCCESS: Upserted jobs")
-				}(scrId, result, name)
-
-				time.Sleep(5 * time.Second)
-				s.SeedCompany.ResultChan <- models.SeedCompanyResult{
-					CompanyName:   name,
-					CompanyURL:    result,
-					SeedCompanyId: scrId,
-				}
-				done <- struct{}{}
-
 			}
 		}(i)
 	}
@@ -459,16 +492,76 @@ func (s *SeedCompanyService) UploadSeedCompanyToChannel(scraper *interfaces.Scra
 	searchWg.Wait()
 }
 
-func CreateSeedCompanyRepo(name string, url string, workerID int, scraper interfaces.ScraperClient) uint {
-	scr := repository.CreateSeedCompanyRepository(name, url)
-	if err := repository.CreateSeedCompany(scr, scraper.DbClient.GetDB()); err != nil {
+// resolveName finds a company's website, stores the company and hands it to
+// the job and testimonial stages. It returns false once ctx is cancelled,
+// after checkpointing the stage the name had reached.
+func (s *SeedCompanyService) resolveName(ctx context.Context, scraper *interfaces.ScraperClient, name string, workerID int) bool {
+	result, err := scraper.Search.SearchKeyword(
+		ctx, name, workerID,
+	)
+
+	if err != nil {
+		if ctx.Err() != nil {
+			checkpointName(scraper, name)
+			return false
+		}
+		logger.Error().Err(err).Int("worker_id", workerID).Msg("error searching google")
+		completeCheckpoint(scraper, models.PendingStageResolve, name)
+		return true
+	}
+
+	if result == "" {
+		logger.Warn().Str("name", name).Msg("empty result, skipping")
+		completeCheckpoint(scraper, models.PendingStageResolve, name)
+		return true
+	}
+
+	logger.Info().Str("url", result).Msg("company url result")
+
+	scrId, result := CreateSeedCompanyRepo(ctx, name, result, workerID, *scraper)
+	company := models.SeedCompanyResult{
+		CompanyName:   name,
+		CompanyURL:    result,
+		SeedCompanyId: scrId,
+	}
+
+	done := make(chan struct{})
+	s.startJobScrape(ctx, scraper, company, done)
+
+	if sleepContext(ctx, envDuration("SEARCH_DELAY", 5*time.Second)) != nil {
+		checkpointCompany(scraper, models.PendingStageTestimonials, company)
+		return false
+	}
+	select {
+	case s.SeedCompany.ResultChan <- company:
+	case <-ctx.Done():
+		checkpointCompany(scraper, models.PendingStageTestimonials, company)
+		return false
+	}
+	close(done)
+
+	completeCheckpoint(scraper, models.PendingStageResolve, name)
+	return true
+}
+
+// CreateSeedCompanyRepo stores the company under its canonical URL, with any
+// redirect to another domain, and returns its ID together with that URL.
+func CreateSeedCompanyRepo(ctx context.Context, name string, url string, workerID int, scraper interfaces.ScraperClient) (uint, string) {
+	siteURL, domain, redirect := CanonicalCompanyURL(ctx, url)
+	if siteURL == "" {
+		siteURL = url
+	}
+
+	scr := repository.CreateSeedCompanyRepository(name, siteURL, domain)
+	scr.RedirectsTo = redirect
+	if err := repository.CreateSeedCompany(scr, scraper.DbClient.GetDB().WithContext(ctx)); err != nil {
 		logger.Error().Err(err).Int("worker_id", workerID).Msg("error creating seed company in DB")
 	}
-	return scr.ID
+	return scr.ID, siteURL
 }
 
-func getJobResults(browser interfaces.BrowserClient, companyUrl string) ([]models.LinkData, error) {
-	return ScrapeJobs(browser, companyUrl)
+func getJobResults(ctx context.Context, scraper *interfaces.ScraperClient, company models.SeedCompanyResult) ([]models.LinkData, error) {
+	return ScrapeJobs(ctx, scraper, company)
 }
 
 func LastWord(text string) string {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"path"
 	"strings"
 	"sync"
 
@@ -18,9 +19,12 @@ type TestimonialService struct {
 	Testimonial *models.Testimonial
 }
 
-func NewTestimonial() *models.Testimonial {
+// Element screenshots taken per page; a logo wall rarely has more
+const maxLogoCaptures = 40
+
+func NewTestimonial(bufferSize int) *models.Testimonial {
 	return &models.Testimonial{
-		ImageResultChan: make(chan models.TestimonialImageResult, 250),
+		ImageResultChan: make(chan models.TestimonialImageResult, bufferSize),
 		TestimonialWg:   &sync.WaitGroup{},
 		ImageWg:         &sync.WaitGroup{},
 	}
@@ -32,8 +36,8 @@ func (t *TestimonialService) ScrapeTestimonial(
 	scChan <-chan models.SeedCompanyResult,
 	vision VisionWrapper,
 ) {
-	numTestimonialWorkers := 4
-	numImageWorkers := 4
+	numTestimonialWorkers := envInt("TESTIMONIAL_WORKERS", 4)
+	numImageWorkers := envInt("IMAGE_WORKERS", 4)
 
 	// Testimonial scraper workers
 	for i := 0; i < numTestimonialWorkers; i++ {
@@ -43,16 +47,6 @@ func (t *TestimonialService) ScrapeTestimonial(
 			defer t.Testimonial.TestimonialWg.Done()
 			logger.Info().Int("worker_id", workerID).Msg("Starting Testimonial worker")
 
-			page, err := scraper.Browser.RunInNewTab()
-			if err != nil {
-				logger.Error().Int("worker_id", workerID).Err(err).Msg("Failed to create page")
-				return
-			}
-			defer func() {
-				logger.Info().Int("worker_id", workerID).Msg("Closing page")
-				page.Close()
-			}()
-
 			for {
 				select {
 				case <-ctx.Done():
@@ -64,18 +58,32 @@ func (t *TestimonialService) ScrapeTestimonial(
 						return
 					}
 
+					// Companies not started yet are left for the next run
+					if draining(scraper) {
+						checkpointCompany(scraper, models.PendingStageTestimonials, scr)
+						continue
+					}
+
 					logger.Info().Int("worker", workerID).Str("company", scr.CompanyName).Msg("Processing")
 
-					urls := t.scrapeCompany(ctx, page, scr)
-					if len(urls) > 0 {
+					images := t.scrapeCompanyWithDeadline(ctx, scraper, scr, workerID)
+					if ctx.Err() != nil {
+						checkpointCompany(scraper, models.PendingStageTestimonials, scr)
+						return
+					}
+					if len(images) == 0 {
+						completeCheckpoint(scraper, models.PendingStageTestimonials, scr.CompanyURL)
+					} else {
 						select {
 						case t.Testimonial.ImageResultChan <- models.TestimonialImageResult{
 							SeedCompanyId: scr.SeedCompanyId,
 							CompanyName:   scr.CompanyName,
-							URL:           urls,
+							CompanyURL:    scr.CompanyURL,
+							Images:        images,
 						}:
 						case <-ctx.Done():
 							logger.Info().Int("worker_id", workerID).Msg("Testimonial worker stopping during send")
+							checkpointCompany(scraper, models.PendingStageTestimonials, scr)
 							return
 						}
 					}
@@ -103,13 +111,18 @@ func (t *TestimonialService) ScrapeTestimonial(
 						return
 					}
 
-					logger.Info().Int("worker", workerID).Str("company", job.CompanyName).Int("count", len(job.URL)).Msg("Processing images")
+					logger.Info().Int("worker", workerID).Str("company", job.CompanyName).Int("count", len(job.Images)).Msg("Processing images")
 
-					for _, url := range job.URL {
-						logger.Info().Str("url", url).Msg("Extracting text from image")
+					for _, img := range job.Images {
+						logger.Info().Str("url", img.URL).Str("alt", img.Alt).Str("link", img.LinkHref).Msg("Extracting text from image")
 					}
 
-					vision.ExtractTextFromImage(job.URL, scraper, workerID, job.SeedCompanyId)
+					vision.ExtractTextFromImage(ctx, job.Images, job.CompanyURL, scraper, workerID, job.SeedCompanyId)
+					if ctx.Err() != nil {
+						checkpointImageResult(scraper, job)
+						return
+					}
+					completeCheckpoint(scraper, models.PendingStageTestimonials, job.CompanyURL)
 				}
 			}
 		}(i)
@@ -121,9 +134,66 @@ func (t *TestimonialService) ScrapeTestimonial(
 
 	t.Testimonial.ImageWg.Wait()
 	logger.Info().Msg("All image workers finished")
+
+	if ctx.Err() != nil {
+		t.checkpointQueued(scraper, scChan)
+	}
 }
 
-func (t *TestimonialService) scrapeCompany(ctx context.Context, page playwright.Page, scr models.SeedCompanyResult) []string {
+// checkpointQueued saves the companies still queued when the run was
+// cancelled. Images are not kept, so their companies are scraped again.
+func (t *TestimonialService) checkpointQueued(scraper *interfaces.ScraperClient, scChan <-chan models.SeedCompanyResult) {
+	for job := range t.Testimonial.ImageResultChan {
+		checkpointImageResult(scraper, job)
+	}
+	for {
+		select {
+		case scr, ok := <-scChan:
+			if !ok {
+				return
+			}
+			checkpointCompany(scraper, models.PendingStageTestimonials, scr)
+		default:
+			return
+		}
+	}
+}
+
+func checkpointImageResult(scraper *interfaces.ScraperClient, job models.TestimonialImageResult) {
+	checkpointCompany(scraper, models.PendingStageTestimonials, models.SeedCompanyResult{
+		CompanyName:   job.CompanyName,
+		CompanyURL:    job.CompanyURL,
+		SeedCompanyId: job.SeedCompanyId,
+	})
+}
+
+// ScrapeCompanyImages collects the candidate logos of one company outside the
+// worker pipeline.
+func (t *TestimonialService) ScrapeCompanyImages(ctx context.Context, scraper *interfaces.ScraperClient, company models.SeedCompanyResult) []models.TestimonialImage {
+	return t.scrapeCompanyWithDeadline(ctx, scraper, company, -1)
+}
+
+// scrapeCompanyWithDeadline opens one tab per company, so record/replay
+// sessions map to a single site, and closes it when the company deadline ends.
+func (t *TestimonialService) scrapeCompanyWithDeadline(ctx context.Context, scraper *interfaces.ScraperClient, scr models.SeedCompanyResult, workerID int) []models.TestimonialImage {
+	companyCtx, cancel := withCompanyDeadline(ctx)
+	defer cancel()
+
+	page, err := scraper.Browser.RunInSession(companyCtx, sessionName("testimonials", scr.CompanyURL))
+	if err != nil {
+		logger.Error().Int("worker_id", workerID).Str("company", scr.CompanyName).Err(err).Msg("Failed to create page")
+		return nil
+	}
+	defer page.Close()
+
+	images := t.scrapeCompany(companyCtx, page, scr)
+	if companyCtx.Err() != nil && ctx.Err() == nil {
+		logger.Warn().Int("worker_id", workerID).Str("company", scr.CompanyName).Msg("Testimonial scrape hit the company deadline")
+	}
+	return images
+}
+
+func (t *TestimonialService) scrapeCompany(ctx context.Context, page playwright.Page, scr models.SeedCompanyResult) []models.TestimonialImage {
 	select {
 	case <-ctx.Done():
 		return nil
@@ -169,6 +239,9 @@ func (t *TestimonialService) scrapeCompany(ctx context.Context, page playwright.
 		return nil
 	}
 
+	// Consent overlays hide the logo strip and skew the logo-size heuristics
+	dismissConsentBanners(page)
+
 	// Wait for images to load
 	page.WaitForTimeout(3000)
 
@@ -219,10 +292,10 @@ func (t *TestimonialService) scrapeCompany(ctx context.Context, page playwright.
 	}
 
 	type testimonialJSResult struct {
-		Found  bool     `json:"found"`
-		Phase  string   `json:"phase"`
-		Count  int      `json:"count"`
-		Images []string `json:"images"`
+		Found  bool                      `json:"found"`
+		Phase  string                    `json:"phase"`
+		Count  int                       `json:"count"`
+		Images []models.TestimonialImage `json:"images"`
 	}
 
 	var data testimonialJSResult
@@ -237,12 +310,28 @@ func (t *TestimonialService) scrapeCompany(ctx context.Context, page playwright.
 		return nil
 	}
 
-	var normalized []string
-	for _, src := range data.Images {
-		full := toAbsoluteURL(pageURL, src)
-		if full != "" {
-			normalized = append(normalized, full)
+	var normalized []models.TestimonialImage
+	captures := 0
+	for _, img := range data.Images {
+		img.URL = toAbsoluteURL(pageURL, img.URL)
+
+		// Inline SVG, CSS background and canvas logos have no bitmap to
+		// download, so screenshot them while the page is still open
+		if img.Capture != "" && captures < maxLogoCaptures {
+			captures++
+			img.Data = captureLogoElement(page, img.Capture)
+		}
+
+		if img.URL == "" && img.Data == nil {
+			continue
+		}
+		if img.LinkHref != "" {
+			img.LinkHref = toAbsoluteURL(pageURL, img.LinkHref)
 		}
+		if img.Filename == "" && img.URL != "" {
+			img.Filename = path.Base(strings.SplitN(img.URL, "?", 2)[0])
+		}
+		normalized = append(normalized, img)
 	}
 
 	if len(normalized) == 0 {
@@ -255,6 +344,21 @@ func (t *TestimonialService) scrapeCompany(ctx context.Context, page playwright.
 	return normalized
 }
 
+// captureLogoElement screenshots an element marked by the testimonial script.
+// It returns nil when the element is gone or cannot be rendered.
+func captureLogoElement(page playwright.Page, id string) []byte {
+	data, err := page.Locator(fmt.Sprintf(`[data-jobloop-logo="%s"]`, id)).First().Screenshot(playwright.LocatorScreenshotOptions{
+		Type:       playwright.ScreenshotTypePng,
+		Animations: playwright.ScreenshotAnimationsDisabled,
+		Timeout:    playwright.Float(5000),
+	})
+	if err != nil {
+		logger.Debug().Str("capture", id).Err(err).Msg("failed to capture logo element")
+		return nil
+	}
+	return data
+}
+
 func toAbsoluteURL(baseURL, src string) string {
 	if src == "" {
 		return ""
@@ -404,6 +508,49 @@ func scrapeTestimonialImageUrls(page playwright.Page) (string, error) {
 	  const isFeatureIconUrl = src =>
 		ICON_HINTS.some(h => safeLower(src).includes(h));
 
+	  const fileName = src => {
+		try {
+		  const parts = new URL(src, location.origin).pathname.split('/');
+		  return decodeURIComponent(parts[parts.length - 1] || '');
+		} catch {
+		  return '';
+		}
+	  };
+
+	  // Everything near the image that may name the company
+	  const describe = (el, src, alt) => {
+		const link = el.closest('a[href]');
+		return {
+		  src,
+		  alt: String(alt || '').trim(),
+		  title: String(el.getAttribute('title') || '').trim(),
+		  ariaLabel: String(el.getAttribute('aria-label') || link?.getAttribute('aria-label') || '').trim(),
+		  linkHref: link ? link.href : '',
+		  filename: fileName(src)
+		};
+	  };
+
+	  // Logos without a fetchable bitmap are marked and captured as element
+	  // screenshots after this script returns
+	  let captureCount = 0;
+	  const markForCapture = el => {
+		let id = el.getAttribute('data-jobloop-logo');
+		if (!id) {
+		  id = String(++captureCount);
+		  el.setAttribute('data-jobloop-logo', id);
+		}
+		return id;
+	  };
+
+	  const backgroundUrl = el => {
+		const m = /url\(["']?([^"')]+)["']?\)/.exec(window.getComputedStyle(el).backgroundImage || '');
+		return m ? m[1] : '';
+	  };
+
+	  // Found once for the whole page; extractVisuals only filters them by root
+	  const backgroundLogos = Array.from(document.body.querySelectorAll('div, span, a, li, figure, i'))
+		.filter(el => backgroundUrl(el) && !el.querySelector('img, svg') && looksLikeLogoSize(el) && !isInHeaderFooterNav(el));
+
 	  const extractVisuals = root => {
 		const results = [];
 
@@ -426,34 +573,59 @@ func scrapeTestimonialImageUrls(page playwright.Page) (string, error) {
 		  if (isFeatureIconUrl(src)) return;
 		  if (!looksLikeLogoSize(img)) return;
 
-		  results.push(src);
+		  const v = describe(img, src, img.getAttribute('alt'));
+		  if (/\.svg($|\?)/i.test(src) || src.startsWith('data:image/svg')) v.capture = markForCapture(img);
+		  results.push(v);
 		});
 
 		root.querySelectorAll('svg').forEach(svg => {
 		  if (isInHeaderFooterNav(svg)) return;
 		  if (!looksLikeLogoSize(svg)) return;
+		  if (svg.parentElement && svg.parentElement.closest('svg')) return;
 
 		  const use = svg.querySelector('use');
-		  if (!use) return;
+		  let href = use ? (use.getAttribute('href') || use.getAttribute('xlink:href') || '') : '';
+		  if (href.startsWith('/')) href = location.origin + href;
+		  if (href && (isNoiseUrl(href) || isFeatureIconUrl(href))) return;
 
-		  let href =
-			use.getAttribute('href') ||
-			use.getAttribute('xlink:href') ||
-			'';
+		  const svgTitle = svg.querySelector('title');
+		  const v = describe(svg, href, svgTitle ? svgTitle.textContent : '');
+		  v.capture = markForCapture(svg);
+		  results.push(v);
+		});
 
-		  if (!href) return;
+		root.querySelectorAll('canvas').forEach(canvas => {
+		  if (isInHeaderFooterNav(canvas)) return;
+		  if (!looksLikeLogoSize(canvas)) return;
 
-		  if (href.startsWith('/')) href = location.origin + href;
-		  if (isNoiseUrl(href)) return;
-		  if (isFeatureIconUrl(href)) return;
+		  const v = describe(canvas, '', '');
+		  v.capture = markForCapture(canvas);
+		  results.push(v);
+		});
 
-		  results.push(href);
+		backgroundLogos.forEach(el => {
+		  if (!root.contains(el)) return;
+
+		  const src = backgroundUrl(el);
+		  if (isNoiseUrl(src) || isFeatureIconUrl(src)) return;
+
+		  const v = describe(el, src, el.getAttribute('title'));
+		  v.capture = markForCapture(el);
+		  results.push(v);
 		});
 
 		return results;
 	  };
 
-	  const dedupe = arr => [...new Set(arr)];
+	  // Captured elements are distinct even when they share a sprite or have no URL
+	  const dedupe = arr => {
+		const byKey = new Map();
+		arr.forEach(v => {
+		  const key = v.capture && (!v.src || !v.src.match(/\.svg($|\?)/i)) ? 'capture:' + v.capture : v.src;
+		  if (!byKey.has(key)) byKey.set(key, v);
+		});
+		return [...byKey.values()];
+	  };
 
 	  /* ================= PHASE 1 ================= */
 
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"gorm.io/gorm"
+
+	"github.com/chandhuDev/JobLoop/internal/interfaces"
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	"github.com/chandhuDev/JobLoop/internal/models"
+	"github.com/chandhuDev/JobLoop/internal/repository"
+	"github.com/chandhuDev/JobLoop/internal/schema"
+)
+
+/* ================= CONFIG ================= */
+
+// ErrBudgetExhausted is returned by AI stages paused by a spend limit.
+var ErrBudgetExhausted = errors.New("LLM budget exhausted")
+
+// modelPrice is USD per million tokens
+type modelPrice struct {
+	Input      float64
+	Output     float64
+	CacheWrite float64
+	CacheRead  float64
+}
+
+var (
+	// Keyed by model ID prefix so dated snapshots share a price
+	modelPrices = map[string]modelPrice{
+		"claude-sonnet-4":   {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.30},
+		"claude-3-7-sonnet": {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.30},
+		"claude-haiku-4":    {Input: 1, Output: 5, CacheWrite: 1.25, CacheRead: 0.10},
+		"claude-3-5-haiku":  {Input: 0.80, Output: 4, CacheWrite: 1, CacheRead: 0.08},
+		"claude-opus-4":     {Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.50},
+	}
+
+	defaultModelPrice = modelPrices["claude-sonnet-4"]
+
+	webSearchPrice = 0.01 // per search
+	batchDiscount  = 0.5  // Message Batches bill tokens at half price
+
+	// Typical tokens of one logo OCR request (image, prompt and tool schema)
+	// and its answer, used to reserve budget for batches until they are collected
+	ocrEstimateInputTokens  = 1800
+	ocrEstimateOutputTokens = 150
+)
+
+/* ================= TRACKER ================= */
+
+// UsageTracker records every Anthropic call in llm_usage and pauses AI stages
+// once the run has spent LLM_RUN_BUDGET_USD or the day (UTC) has spent
+// LLM_DAILY_BUDGET_USD. A zero or unset limit means no limit. The estimated
+// cost of submitted batches counts as spent until they are collected.
+type UsageTracker struct {
+	DB          *gorm.DB
+	RunID       string
+	RunBudget   float64
+	DailyBudget float64
+
+	mu       sync.Mutex
+	runSpend float64
+	daySpend float64
+	day      time.Time
+	paused   map[string]bool
+	reserved map[string]float64
+}
+
+func NewUsageTracker(db *gorm.DB, runID string) *UsageTracker {
+	return &UsageTracker{
+		DB:          db,
+		RunID:       runID,
+		RunBudget:   envBudget("LLM_RUN_BUDGET_USD"),
+		DailyBudget: envBudget("LLM_DAILY_BUDGET_USD"),
+		paused:      make(map[string]bool),
+		reserved:    make(map[string]float64),
+	}
+}
+
+func envBudget(env string) float64 {
+	budget, err := strconv.ParseFloat(os.Getenv(env), 64)
+	if err != nil || budget < 0 {
+		return 0
+	}
+	return budget
+}
+
+// Allow reports whether the stage may make another call. The first refusal
+// per stage is logged so a paused stage is visible without flooding the log.
+func (u *UsageTracker) Allow(stage string) bool {
+	return u.AllowCost(stage, 0)
+}
+
+// AllowCost reports whether the stage may spend an estimated usd more.
+func (u *UsageTracker) AllowCost(stage string, usd float64) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.syncDay()
+
+	pending := usd
+	for _, cost := range u.reserved {
+		pending += cost
+	}
+
+	reason := ""
+	switch {
+	case u.RunBudget > 0 && u.runSpend+pending >= u.RunBudget:
+		reason = "run"
+	case u.DailyBudget > 0 && u.daySpend+pending >= u.DailyBudget:
+		reason = "daily"
+	}
+	if reason == "" {
+		return true
+	}
+
+	if !u.paused[stage] {
+		u.paused[stage] = true
+		logger.Warn().
+			Str("stage", stage).
+			Str("budget", reason).
+			Float64("run_spend_usd", u.runSpend).
+			Float64("day_spend_usd", u.daySpend).
+			Float64("reserved_usd", pending-usd).
+			Msg("LLM budget reached, pausing stage")
+	}
+	return false
+}
+
+// Reserve counts the estimated cost of a submitted batch against both limits
+// until Release replaces it with the usage recorded at collection.
+func (u *UsageTracker) Reserve(key string, usd float64) {
+	u.mu.Lock()
+	u.reserved[key] += usd
+	u.mu.Unlock()
+}
+
+func (u *UsageTracker) Release(key string) {
+	u.mu.Lock()
+	delete(u.reserved, key)
+	u.mu.Unlock()
+}
+
+// Record counts a call against the limits and stores it. A call with a
+// RequestID that is already stored, such as a batch result collected a
+// second time, is skipped.
+func (u *UsageTracker) Record(usage models.LLMUsage) {
+	cost := usageCost(usage)
+	row := &schema.LLMUsage{
+		RunID:                    u.RunID,
+		Stage:                    usage.Stage,
+		CompanyName:              usage.CompanyName,
+		SeedCompanyID:            usage.SeedCompanyID,
+		Model:                    usage.Model,
+		Batch:                    usage.Batch,
+		InputTokens:              usage.Usage.InputTokens,
+		OutputTokens:             usage.Usage.OutputTokens,
+		CacheCreationInputTokens: usage.Usage.CacheCreationInputTokens,
+		CacheReadInputTokens:     usage.Usage.CacheReadInputTokens,
+		WebSearchRequests:        usage.Usage.ServerToolUse.WebSearchRequests,
+		CostUSD:                  cost,
+	}
+
+	// The day is synced before saving so a reload from the database cannot
+	// count this call twice
+	u.mu.Lock()
+	u.syncDay()
+	u.mu.Unlock()
+
+	if u.DB != nil {
+		var err error
+		inserted := true
+		if usage.RequestID != "" {
+			row.RequestID = &usage.RequestID
+			inserted, err = repository.SaveLLMUsageOnce(u.DB, row)
+		} else {
+			err = repository.SaveLLMUsage(u.DB, row)
+		}
+		if err != nil {
+			logger.Warn().Err(err).Str("stage", usage.Stage).Msg("failed to record LLM usage")
+		} else if !inserted {
+			logger.Debug().Str("request_id", usage.RequestID).Msg("LLM usage already recorded")
+			return
+		}
+	}
+
+	u.mu.Lock()
+	u.runSpend += cost
+	u.daySpend += cost
+	u.mu.Unlock()
+}
+
+// syncDay reloads today's spend from the database when the UTC day changes,
+// so calls made by earlier runs today count against the daily limit.
+// Callers hold u.mu.
+func (u *UsageTracker) syncDay() {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if u.day.Equal(today) {
+		return
+	}
+	u.day = today
+	u.daySpend = 0
+
+	if u.DB == nil {
+		return
+	}
+	spend, err := repository.LLMSpendSince(u.DB, today)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to load today's LLM spend")
+		return
+	}
+	u.daySpend = spend
+}
+
+/* ================= PRICING ================= */
+
+func usageCost(usage models.LLMUsage) float64 {
+	price := priceForModel(usage.Model)
+	tokens := usage.Usage
+
+	cost := (float64(tokens.InputTokens)*price.Input +
+		float64(tokens.OutputTokens)*price.Output +
+		float64(tokens.CacheCreationInputTokens)*price.CacheWrite +
+		float64(tokens.CacheReadInputTokens)*price.CacheRead) / 1e6
+	if usage.Batch {
+		cost *= batchDiscount
+	}
+
+	return cost + float64(tokens.ServerToolUse.WebSearchRequests)*webSearchPrice
+}
+
+// estimateOCRBatchCost is what a batch of n logo OCR requests is expected to
+// cost before its usage is known.
+func estimateOCRBatchCost(model string, n int) float64 {
+	price := priceForModel(model)
+	perImage := (float64(ocrEstimateInputTokens)*price.Input + float64(ocrEstimateOutputTokens)*price.Output) / 1e6
+	return float64(n) * perImage * batchDiscount
+}
+
+func priceForModel(model string) modelPrice {
+	best := ""
+	for prefix := range modelPrices {
+		if strings.HasPrefix(model, prefix) && len(prefix) > len(best) {
+			best = prefix
+		}
+	}
+	if best == "" {
+		return defaultModelPrice
+	}
+	return modelPrices[best]
+}
+
+/* ================= HELPERS ================= */
+
+// usageAllowed and recordUsage treat a missing recorder as unlimited and
+// unaccounted, so providers built without a tracker keep working.
+func usageAllowed(recorder interfaces.UsageRecorder, stage string) bool {
+	return recorder == nil || recorder.Allow(stage)
+}
+
+func recordUsage(recorder interfaces.UsageRecorder, usage models.LLMUsage) {
+	if recorder != nil {
+		recorder.Record(usage)
+	}
+}
+
+// costAllowed, reserveUsage and releaseUsage fall back to plain accounting
+// for recorders that cannot hold reservations.
+func costAllowed(recorder interfaces.UsageRecorder, stage string, usd float64) bool {
+	if reserver, ok := recorder.(interfaces.UsageReserver); ok {
+		return reserver.AllowCost(stage, usd)
+	}
+	return usageAllowed(recorder, stage)
+}
+
+func reserveUsage(recorder interfaces.UsageRecorder, key string, usd float64) {
+	if reserver, ok := recorder.(interfaces.UsageReserver); ok {
+		reserver.Reserve(key, usd)
+	}
+}
+
+func releaseUsage(recorder interfaces.UsageRecorder, key string) {
+	if reserver, ok := recorder.(interfaces.UsageReserver); ok {
+		reserver.Release(key)
+	}
+}
+
+// messageUsage converts the usage block of a Messages response.
+func messageUsage(usage anthropic.Usage) models.TokenUsage {
+	return models.TokenUsage{
+		InputTokens:              usage.InputTokens,
+		OutputTokens:             usage.OutputTokens,
+		CacheCreationInputTokens: usage.CacheCreationInputTokens,
+		CacheReadInputTokens:     usage.CacheReadInputTokens,
+		ServerToolUse:            models.ServerToolUsage{WebSearchRequests: usage.ServerToolUse.WebSearchRequests},
+	}
+}
@@ -0,0 +1,363 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"gorm.io/gorm"
+
+	"github.com/chandhuDev/JobLoop/internal/interfaces"
+	"github.com/chandhuDev/JobLoop/internal/logger"
+	models "github.com/chandhuDev/JobLoop/internal/models"
+)
+
+/* ================= CONFIG ================= */
+
+const (
+	defaultVisionModel = string(anthropic.ModelClaudeSonnet4_5_20250929)
+
+	// Concurrent Messages calls made by the realtime provider
+	// (VISION_REALTIME_CONCURRENCY overrides it)
+	realtimeVisionConcurrency = 4
+)
+
+// batchResultsClient downloads batch results; a stalled download gives up
+// instead of holding a worker forever
+var batchResultsClient = &http.Client{Timeout: 5 * time.Minute}
+
+// NewVisionProvider builds the provider named in VISION_PROVIDER: "batch"
+// (default, half price but minutes of latency), "realtime" or "fake". The
+// Anthropic providers use VISION_MODEL; the fake reads VISION_FAKE_FILE.
+// With a database the batch provider queues images across companies and
+// collects results in the background; see OCRBatcher.
+func NewVisionProvider(client *anthropic.Client, db *gorm.DB, names *models.NamesClient, usage interfaces.UsageRecorder) (interfaces.VisionClient, error) {
+	model := envString("VISION_MODEL", defaultVisionModel)
+
+	switch provider := strings.TrimSpace(os.Getenv("VISION_PROVIDER")); provider {
+	case "", "batch":
+		if db != nil {
+			return NewOCRBatcher(client, model, db, names, usage), nil
+		}
+		return &AnthropicBatchVision{Client: client, Model: model, Usage: usage}, nil
+	case "realtime":
+		return &AnthropicRealtimeVision{Client: client, Model: model, Usage: usage}, nil
+	case "fake":
+		fake, err := LoadFakeVision(os.Getenv("VISION_FAKE_FILE"))
+		if err != nil {
+			return nil, err
+		}
+		return fake, nil
+	default:
+		return nil, fmt.Errorf("unknown vision provider %q", provider)
+	}
+}
+
+/* ================= ANTHROPIC BATCH ================= */
+
+type AnthropicBatchVision struct {
+	Client *anthropic.Client
+	Model  string
+	Usage  interfaces.UsageRecorder
+}
+
+func (a *AnthropicBatchVision) Name() string {
+	return "batch"
+}
+
+// Recognize submits every request as one Message Batch and waits for it to end.
+func (a *AnthropicBatchVision) Recognize(ctx context.Context, requests []models.OCRRequest) ([]models.OCRResult, error) {
+	estimate := estimateOCRBatchCost(a.Model, len(requests))
+	if !costAllowed(a.Usage, models.UsageStageVision, estimate) {
+		return nil, ErrBudgetExhausted
+	}
+
+	batchRequests := make([]anthropic.MessageBatchNewParamsRequest, 0, len(requests))
+	urlMap := make(map[string]string, len(requests))
+	companies := make(map[string]uint, len(requests))
+	for _, req := range requests {
+		batchRequests = append(batchRequests, ocrBatchRequest(a.Model, req))
+		urlMap[req.CustomID] = req.ImageURL
+		companies[req.CustomID] = req.SeedCompanyID
+	}
+
+	messageBatch, err := a.Client.Messages.Batches.New(ctx, anthropic.MessageBatchNewParams{
+		Requests: batchRequests,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("submit batch: %w", err)
+	}
+
+	logger.Info().Str("batch_id", messageBatch.ID).Msg("created message batch for OCR")
+
+	reserveUsage(a.Usage, messageBatch.ID, estimate)
+	defer releaseUsage(a.Usage, messageBatch.ID)
+
+	if err := pollBatch(ctx, a.Client, messageBatch.ID); err != nil {
+		return nil, err
+	}
+
+	results, err := getResults(ctx, messageBatch.ID, urlMap)
+	for _, result := range results {
+		recordUsage(a.Usage, models.LLMUsage{
+			Stage:         models.UsageStageVision,
+			SeedCompanyID: companies[result.CustomID],
+			Model:         a.Model,
+			Batch:         true,
+			Usage:         result.Usage,
+		})
+	}
+	return results, err
+}
+
+func ocrBatchRequest(model string, req models.OCRRequest) anthropic.MessageBatchNewParamsRequest {
+	params := ocrParams(model, req)
+	return anthropic.MessageBatchNewParamsRequest{
+		CustomID: req.CustomID,
+		Params: anthropic.MessageBatchNewParamsRequestParams{
+			MaxTokens:  params.MaxTokens,
+			Model:      params.Model,
+			Tools:      params.Tools,
+			ToolChoice: params.ToolChoice,
+			Messages:   params.Messages,
+		},
+	}
+}
+
+// pollBatch waits for a batch to end, or for ctx to be cancelled.
+func pollBatch(ctx context.Context, client *anthropic.Client, batchID string) error {
+	logger.Info().Str("batch_id", batchID).Msg("polling batch status")
+
+	for {
+		batch, err := client.Messages.Batches.Get(ctx, batchID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Error().Err(err).Msg("error polling batch")
+			if err := sleepContext(ctx, 5*time.Second); err != nil {
+				return err
+			}
+			continue
+		}
+
+		total := batch.RequestCounts.Succeeded + batch.RequestCounts.Errored + batch.RequestCounts.Processing
+		completed := batch.RequestCounts.Succeeded + batch.RequestCounts.Errored
+
+		logger.Info().
+			Str("status", string(batch.ProcessingStatus)).
+			Int64("completed", completed).
+			Int64("total", total).
+			Msg("batch progress")
+
+		if batch.ProcessingStatus == "ended" {
+			logger.Info().Str("batch_id", batchID).Msg("batch processing ended")
+			return nil
+		}
+
+		if err := sleepContext(ctx, 5*time.Second); err != nil {
+			return err
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func getResults(ctx context.Context, batchID string, urlMap map[string]string) ([]models.OCRResult, error) {
+	apiKey := os.Getenv("ANTHROPIC_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("ANTHROPIC_API_KEY not set")
+	}
+
+	url := fmt.Sprintf("https://api.anthropic.com/v1/messages/batches/%s/results", batchID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := batchResultsClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var results []models.OCRResult
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 2*1024*1024)
+
+	for scanner.Scan() {
+		var batchResult BatchResult
+		if err := json.Unmarshal(scanner.Bytes(), &batchResult); err != nil {
+			continue
+		}
+
+		originalURL, exists := urlMap[batchResult.CustomID]
+		if !exists {
+			continue
+		}
+
+		if batchResult.Result.Type == "succeeded" {
+			results = append(results, models.OCRResult{
+				CustomID: batchResult.CustomID,
+				ImageURL: originalURL,
+				Names:    parseRecognizedNames(batchResult.CustomID, batchResult.Result.Message.Content),
+				Usage:    batchResult.Result.Message.Usage,
+			})
+		} else {
+			results = append(results, models.OCRResult{
+				CustomID: batchResult.CustomID,
+				ImageURL: originalURL,
+				Error:    fmt.Errorf("%s: %s", batchResult.Result.Error.Type, batchResult.Result.Error.Message),
+			})
+		}
+	}
+
+	return results, scanner.Err()
+}
+
+/* ================= ANTHROPIC REALTIME ================= */
+
+type AnthropicRealtimeVision struct {
+	Client *anthropic.Client
+	Model  string
+	Usage  interfaces.UsageRecorder
+}
+
+func (a *AnthropicRealtimeVision) Name() string {
+	return "realtime"
+}
+
+// Recognize sends one Messages call per image, a few at a time. It costs
+// twice the batch price but answers in seconds.
+func (a *AnthropicRealtimeVision) Recognize(ctx context.Context, requests []models.OCRRequest) ([]models.OCRResult, error) {
+	results := make([]models.OCRResult, len(requests))
+	sem := make(chan struct{}, envInt("VISION_REALTIME_CONCURRENCY", realtimeVisionConcurrency))
+	var wg sync.WaitGroup
+
+	for i, req := range requests {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(i int, req models.OCRRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i] = models.OCRResult{CustomID: req.CustomID, ImageURL: req.ImageURL}
+
+			if !usageAllowed(a.Usage, models.UsageStageVision) {
+				results[i].Error = ErrBudgetExhausted
+				return
+			}
+
+			msg, err := a.Client.Messages.New(ctx, ocrParams(a.Model, req))
+			if err != nil {
+				results[i].Error = err
+				return
+			}
+
+			results[i].Usage = messageUsage(msg.Usage)
+			recordUsage(a.Usage, models.LLMUsage{
+				Stage:         models.UsageStageVision,
+				SeedCompanyID: req.SeedCompanyID,
+				Model:         string(msg.Model),
+				Usage:         results[i].Usage,
+			})
+
+			blocks := make([]ocrContentBlock, 0, len(msg.Content))
+			for _, block := range msg.Content {
+				blocks = append(blocks, ocrContentBlock{Type: block.Type, Text: block.Text, Name: block.Name, Input: block.Input})
+			}
+			results[i].Names = parseRecognizedNames(req.CustomID, blocks)
+		}(i, req)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+/* ================= FAKE ================= */
+
+// FakeVision answers from a fixed table so the testimonial pipeline can run
+// offline. Names are looked up by the image's SHA-256, then its URL, then its
+// file name; unknown images are recognised as having no names.
+type FakeVision struct {
+	Names map[string][]string
+}
+
+func (f *FakeVision) Name() string {
+	return "fake"
+}
+
+func (f *FakeVision) Recognize(ctx context.Context, requests []models.OCRRequest) ([]models.OCRResult, error) {
+	results := make([]models.OCRResult, 0, len(requests))
+	for _, req := range requests {
+		result := models.OCRResult{CustomID: req.CustomID, ImageURL: req.ImageURL, Names: []models.RecognizedName{}}
+
+		for _, key := range []string{req.ContentHash, req.ImageURL, path.Base(strings.SplitN(req.ImageURL, "?", 2)[0])} {
+			names, ok := f.Names[key]
+			if key == "" || !ok {
+				continue
+			}
+			for _, name := range names {
+				result.Names = append(result.Names, models.RecognizedName{Name: name, Confidence: 1, IsLogo: true})
+			}
+			break
+		}
+
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// LoadFakeVision reads a JSON object of image hash, URL or file name to the
+// names in that image. An empty path gives a fake that recognises nothing.
+func LoadFakeVision(path string) (*FakeVision, error) {
+	if path == "" {
+		return &FakeVision{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var names map[string][]string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, fmt.Errorf("invalid vision fixtures %s: %w", path, err)
+	}
+	return &FakeVision{Names: names}, nil
+}
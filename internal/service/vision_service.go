@@ -1,20 +1,16 @@
 package service
 
 import (
-	"bufio"
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
-	"time"
-
-	"github.com/playwright-community/playwright-go"
+	"unicode"
 
 	"github.com/chandhuDev/JobLoop/internal/interfaces"
 	"github.com/chandhuDev/JobLoop/internal/logger"
@@ -23,18 +19,41 @@ import (
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
+	"gorm.io/gorm"
 )
 
 var fileMutex sync.Mutex
 
+const (
+	ocrToolName = "report_company_names"
+
+	// Names below this confidence are usually taglines or partial words
+	minNameConfidence = 0.6
+	maxNameLength     = 60
+)
+
+var (
+	ocrPrompt = "Identify every company or product name shown in this image, one entry per logo or wordmark. Report them with the report_company_names tool."
+
+	// Words the model sometimes returns for decorative text rather than a company
+	genericNames = map[string]bool{
+		"logo": true, "company": true, "customer": true, "customers": true, "partner": true,
+		"partners": true, "trusted by": true, "brand": true, "unknown": true,
+	}
+
+	nameSeparators = strings.NewReplacer("\n", "\x00", ";", "\x00", " | ", "\x00", " · ", "\x00", " • ", "\x00")
+)
+
 type VisionWrapper struct {
-	Vision *models.Vision
+	Vision   *models.Vision
+	Provider interfaces.VisionClient
 }
 
-type OCRResult struct {
-	ImageURL string
-	Text     string
-	Error    error
+type ocrContentBlock struct {
+	Type  string          `json:"type"`
+	Text  string          `json:"text"`
+	Name  string          `json:"name"`
+	Input json.RawMessage `json:"input"`
 }
 
 type BatchResult struct {
@@ -42,10 +61,9 @@ type BatchResult struct {
 	Result   struct {
 		Type    string `json:"type"`
 		Message struct {
-			Content []struct {
-				Type string `json:"type"`
-				Text string `json:"text"`
-			} `json:"content"`
+			Model   string            `json:"model"`
+			Content []ocrContentBlock `json:"content"`
+			Usage   models.TokenUsage `json:"usage"`
 		} `json:"message"`
 		Error struct {
 			Type    string `json:"type"`
@@ -69,275 +87,287 @@ func CreateVisionInstance() *anthropic.Client {
 	return &vClient
 }
 
+// ExtractTextFromImage names the companies behind a company's logos, stores
+// them as its testimonials and returns them. With a queued provider the names
+// of images sent for OCR arrive later through the batch collector instead.
 func (v *VisionWrapper) ExtractTextFromImage(
-	imageURLs []string,
+	ctx context.Context,
+	images []models.TestimonialImage,
+	companyURL string,
 	scraper *interfaces.ScraperClient,
 	workerID int,
 	seedCompanyId uint,
-) {
-	if len(imageURLs) == 0 {
+) []string {
+	if len(images) == 0 {
 		logger.Info().Int("worker_id", workerID).Msg("no images to process")
-		return
+		return nil
 	}
 
-	logger.Info().Int("worker_id", workerID).Int("image_count", len(imageURLs)).Uint("seed_company_id", seedCompanyId).Msg("starting vision scraper")
-
-	requests, urlMap, err := createOCRRequests(imageURLs, scraper.Browser)
-	if err != nil {
-		logger.Error().Err(err).Msg("error creating OCR requests")
-		return
-	}
+	logger.Info().Int("worker_id", workerID).Int("image_count", len(images)).Uint("seed_company_id", seedCompanyId).Msg("starting vision scraper")
 
-	if len(requests) == 0 {
-		logger.Warn().Int("worker_id", workerID).Msg("no valid requests created")
-		return
-	}
+	db := scraper.DbClient.GetDB().WithContext(ctx)
 
-	logger.Info().Int("request_count", len(requests)).Msg("submitting images for OCR")
+	var testimonials []string
+	seen := make(map[string]bool)
+	addNames := func(names []string) {
+		for _, name := range names {
+			key := strings.ToLower(name)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
 
-	messageBatch, err := v.Vision.VisionClient.Messages.Batches.New(context.TODO(), anthropic.MessageBatchNewParams{
-		Requests: requests,
-	})
-	if err != nil {
-		logger.Error().Err(err).Msg("error submitting batch")
-		return
+			testimonials = append(testimonials, name)
+			select {
+			case scraper.NamesChanClient.NamesChan <- name:
+			case <-ctx.Done():
+			}
+		}
 	}
 
-	logger.Info().Str("batch_id", messageBatch.ID).Msg("created message batch for OCR")
+	// Alt text, links and filenames name most logos without OCR
+	signalNames, unresolved := SplitBySignals(images, companyURL)
+	addNames(signalNames)
 
-	pollBatch(v.Vision.VisionClient, messageBatch.ID)
+	logger.Info().Int("worker_id", workerID).Int("from_signals", len(signalNames)).Int("unresolved", len(unresolved)).Msg("resolved logos from image metadata")
 
-	results, err := getResults(messageBatch.ID, urlMap)
-	if err != nil {
-		logger.Error().Err(err).Msg("error getting results")
-		return
-	}
+	// Download each image once and answer from the cache where possible
+	var pending []*ocrImage
+	loaded := make(map[string]bool)
+	cacheHits := 0
+	for _, candidate := range unresolved {
+		if ctx.Err() != nil {
+			return testimonials
+		}
 
-	var testimonials []string
-	for _, result := range results {
-		if result.Error != nil {
-			logger.Warn().Str("url", result.ImageURL).Err(result.Error).Msg("OCR failed for image")
+		img, err := loadOCRImage(ctx, candidate)
+		if err != nil {
+			logger.Warn().Str("url", candidate.URL).Str("capture", candidate.Capture).Err(err).Msg("failed to load image, skipping")
 			continue
 		}
-		if len(result.Text) > 25 {
-			logger.Warn().Str("url", result.ImageURL).Int("text_length", len(result.Text)).Msg("extracted text is too long likely not just company names")
+		if loaded[img.ContentHash] {
 			continue
 		}
-		scraper.NamesChanClient.NamesChan <- result.Text
+		loaded[img.ContentHash] = true
 
-		testimonials = append(testimonials, result.Text)
+		if names, ok := cachedImageNames(db, img); ok {
+			cacheHits++
+			addNames(names)
+			continue
+		}
+		pending = append(pending, img)
+	}
+
+	logger.Info().Int("worker_id", workerID).Int("cache_hits", cacheHits).Int("pending", len(pending)).Msg("image cache checked")
+
+	if queue, ok := v.Provider.(interfaces.QueuedVisionClient); ok && len(pending) > 0 {
+		// Names arrive later through the batch collector
+		requests, _ := createOCRRequests(pending, seedCompanyId)
+		if err := queue.Enqueue(ctx, seedCompanyId, requests); err != nil {
+			logger.Error().Err(err).Uint("seed_company_id", seedCompanyId).Msg("failed to queue images for OCR")
+		}
+	} else if len(pending) > 0 {
+		addNames(v.recognizeImages(ctx, pending, db, seedCompanyId))
 	}
 
 	if len(testimonials) > 0 {
-		if err := repository.BulkUpsertTestimonials(scraper.DbClient.GetDB(), seedCompanyId, testimonials); err != nil {
+		if err := repository.BulkUpsertTestimonials(db, seedCompanyId, testimonials); err != nil {
 			logger.Error().Err(err).Msg("error upserting testimonial images")
-			return
+			return testimonials
 		}
 	}
 
 	logger.Info().Int("worker_id", workerID).Int("results", len(testimonials)).Uint("seed_company_id", seedCompanyId).Msg("vision processing completed")
+	return testimonials
 }
 
-func createOCRRequests(imageURLs []string, browser interfaces.BrowserClient) ([]anthropic.MessageBatchNewParamsRequest, map[string]string, error) {
-	var requests []anthropic.MessageBatchNewParamsRequest
-	urlMap := make(map[string]string)
+// recognizeImages sends the images the cache could not answer to the vision
+// provider and caches every result, including images with no names. Answers
+// from the fake provider are never cached, so a fixture run against a real
+// database cannot blank logos for the real provider.
+func (v *VisionWrapper) recognizeImages(ctx context.Context, images []*ocrImage, db *gorm.DB, seedCompanyId uint) []string {
+	requests, imageMap := createOCRRequests(images, seedCompanyId)
 
-	for i, url := range imageURLs {
-		customID := fmt.Sprintf("ocr-%d", i)
-		urlMap[customID] = url
+	cacheDB := db
+	if _, fake := v.Provider.(*FakeVision); fake {
+		cacheDB = nil
+	}
 
-		ext := getExtFromURL(url)
+	logger.Info().Str("provider", v.Provider.Name()).Int("request_count", len(requests)).Msg("submitting images for OCR")
 
-		if ext == ".svg" {
-			imageBytes, err := convertSVGtoPNG(browser, url)
-			if err != nil {
-				logger.Warn().Str("url", url).Err(err).Msg("failed to convert SVG, skipping")
-				delete(urlMap, customID)
-				continue
-			}
+	results, err := v.Provider.Recognize(ctx, requests)
+	if err != nil {
+		logger.Error().Str("provider", v.Provider.Name()).Err(err).Msg("OCR request failed")
+		return nil
+	}
 
-			base64Image := base64.StdEncoding.EncodeToString(imageBytes)
-			logger.Info().Str("url", url).Msg("converted SVG to PNG")
-
-			requests = append(requests, anthropic.MessageBatchNewParamsRequest{
-				CustomID: customID,
-				Params: anthropic.MessageBatchNewParamsRequestParams{
-					MaxTokens: 4096,
-					Model:     anthropic.ModelClaudeSonnet4_5_20250929,
-					Messages: []anthropic.MessageParam{
-						{
-							Role: anthropic.MessageParamRoleUser,
-							Content: []anthropic.ContentBlockParamUnion{
-								{
-									OfImage: &anthropic.ImageBlockParam{
-										Type: "image",
-										Source: anthropic.ImageBlockParamSourceUnion{
-											OfBase64: &anthropic.Base64ImageSourceParam{
-												Type:      "base64",
-												MediaType: "image/png",
-												Data:      base64Image,
-											},
-										},
-									},
-								},
-								{
-									OfText: &anthropic.TextBlockParam{
-										Type: "text",
-										Text: "Extract all company names from this image. Return only the company names, one per line.",
+	var names []string
+	for _, result := range results {
+		if errors.Is(result.Error, ErrBudgetExhausted) {
+			continue
+		}
+		if result.Error != nil {
+			logger.Warn().Str("url", result.ImageURL).Err(result.Error).Msg("OCR failed for image")
+			continue
+		}
+
+		accepted := acceptedNames(result.Names)
+		if img, ok := imageMap[result.CustomID]; ok {
+			cacheImageNames(cacheDB, img, accepted)
+		}
+		names = append(names, accepted...)
+	}
+	return names
+}
+
+// createOCRRequests sends the downloaded bytes rather than the image URL so
+// the provider sees exactly the image that was hashed.
+func createOCRRequests(images []*ocrImage, seedCompanyId uint) ([]models.OCRRequest, map[string]*ocrImage) {
+	var requests []models.OCRRequest
+	imageMap := make(map[string]*ocrImage)
+
+	for i, img := range images {
+		customID := fmt.Sprintf("ocr-%d", i)
+		imageMap[customID] = img
+
+		requests = append(requests, models.OCRRequest{
+			CustomID:       customID,
+			SeedCompanyID:  seedCompanyId,
+			ImageURL:       img.URL,
+			ContentHash:    img.ContentHash,
+			PerceptualHash: img.PerceptualHash,
+			MediaType:      img.MediaType,
+			Data:           img.Data,
+		})
+	}
+
+	return requests, imageMap
+}
+
+// ocrParams asks for the names in one image and forces the reply through the
+// report_company_names tool so it arrives as structured JSON.
+func ocrParams(model string, req models.OCRRequest) anthropic.MessageNewParams {
+	return anthropic.MessageNewParams{
+		MaxTokens: 1024,
+		Model:     anthropic.Model(model),
+		Tools: []anthropic.ToolUnionParam{
+			{
+				OfTool: &anthropic.ToolParam{
+					Name:        ocrToolName,
+					Description: anthropic.String("Report the company names visible in the image."),
+					InputSchema: anthropic.ToolInputSchemaParam{
+						Properties: map[string]any{
+							"names": map[string]any{
+								"type": "array",
+								"items": map[string]any{
+									"type": "object",
+									"properties": map[string]any{
+										"name":       map[string]any{"type": "string", "description": "Company name exactly as written, without words like logo or Inc."},
+										"confidence": map[string]any{"type": "number", "minimum": 0, "maximum": 1},
+										"is_logo":    map[string]any{"type": "boolean", "description": "True when the name is a company logo or wordmark, false for taglines, headings or body text."},
 									},
+									"required": []string{"name", "confidence", "is_logo"},
 								},
 							},
 						},
+						Required: []string{"names"},
 					},
 				},
-			})
-		} else {
-			requests = append(requests, anthropic.MessageBatchNewParamsRequest{
-				CustomID: customID,
-				Params: anthropic.MessageBatchNewParamsRequestParams{
-					MaxTokens: 4096,
-					Model:     anthropic.ModelClaudeSonnet4_5_20250929,
-					Messages: []anthropic.MessageParam{
-						{
-							Role: anthropic.MessageParamRoleUser,
-							Content: []anthropic.ContentBlockParamUnion{
-								{
-									OfImage: &anthropic.ImageBlockParam{
-										Type: "image",
-										Source: anthropic.ImageBlockParamSourceUnion{
-											OfURL: &anthropic.URLImageSourceParam{
-												Type: "url",
-												URL:  url,
-											},
-										},
-									},
-								},
-								{
-									OfText: &anthropic.TextBlockParam{
-										Type: "text",
-										Text: "Extract all company names from this image. Return only the company names, one per line.",
-									},
+			},
+		},
+		ToolChoice: anthropic.ToolChoiceUnionParam{
+			OfTool: &anthropic.ToolChoiceToolParam{Name: ocrToolName},
+		},
+		Messages: []anthropic.MessageParam{
+			{
+				Role: anthropic.MessageParamRoleUser,
+				Content: []anthropic.ContentBlockParamUnion{
+					{
+						OfImage: &anthropic.ImageBlockParam{
+							Type: "image",
+							Source: anthropic.ImageBlockParamSourceUnion{
+								OfBase64: &anthropic.Base64ImageSourceParam{
+									Type:      "base64",
+									MediaType: anthropic.Base64ImageSourceMediaType(req.MediaType),
+									Data:      base64.StdEncoding.EncodeToString(req.Data),
 								},
 							},
 						},
 					},
+					{
+						OfText: &anthropic.TextBlockParam{
+							Type: "text",
+							Text: ocrPrompt,
+						},
+					},
 				},
-			})
-		}
+			},
+		},
 	}
-
-	return requests, urlMap, nil
 }
 
-func convertSVGtoPNG(browser interfaces.BrowserClient, svgURL string) ([]byte, error) {
-	page, err := browser.RunInNewTab()
-	if err != nil {
-		return nil, err
-	}
-	defer page.Close()
-
-	if _, err := page.Goto(svgURL); err != nil {
-		return nil, err
+// parseRecognizedNames reads the report_company_names tool input. A plain text
+// reply is still accepted, one name per line, with unknown confidence.
+func parseRecognizedNames(customID string, blocks []ocrContentBlock) []models.RecognizedName {
+	var names []models.RecognizedName
+	for _, block := range blocks {
+		switch {
+		case block.Type == "tool_use" && block.Name == ocrToolName:
+			var input struct {
+				Names []models.RecognizedName `json:"names"`
+			}
+			if err := json.Unmarshal(block.Input, &input); err != nil {
+				logger.Warn().Err(err).Str("custom_id", customID).Msg("invalid OCR tool input")
+				continue
+			}
+			names = append(names, input.Names...)
+		case block.Type == "text":
+			for _, line := range strings.Split(block.Text, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					names = append(names, models.RecognizedName{Name: line, Confidence: minNameConfidence, IsLogo: true})
+				}
+			}
+		}
 	}
-
-	return page.Screenshot(playwright.PageScreenshotOptions{
-		Type: playwright.ScreenshotTypePng,
-	})
+	return names
 }
 
-func pollBatch(client *anthropic.Client, batchID string) {
-	logger.Info().Str("batch_id", batchID).Msg("polling batch status")
-
-	for {
-		batch, err := client.Messages.Batches.Get(context.TODO(), batchID)
-		if err != nil {
-			logger.Error().Err(err).Msg("error polling batch")
-			time.Sleep(5 * time.Second)
+// acceptedNames splits entries that hold several names, normalises each one
+// and keeps the confident logo names.
+func acceptedNames(names []models.RecognizedName) []string {
+	var accepted []string
+	for _, n := range names {
+		if !n.IsLogo || n.Confidence < minNameConfidence {
 			continue
 		}
-
-		total := batch.RequestCounts.Succeeded + batch.RequestCounts.Errored + batch.RequestCounts.Processing
-		completed := batch.RequestCounts.Succeeded + batch.RequestCounts.Errored
-
-		logger.Info().
-			Str("status", string(batch.ProcessingStatus)).
-			Int64("completed", completed).
-			Int64("total", total).
-			Msg("batch progress")
-
-		if batch.ProcessingStatus == "ended" {
-			logger.Info().Str("batch_id", batchID).Msg("batch processing ended")
-			return
+		for _, part := range strings.Split(nameSeparators.Replace(n.Name), "\x00") {
+			if name := normalizeRecognizedName(part); name != "" {
+				accepted = append(accepted, name)
+			}
 		}
-
-		time.Sleep(5 * time.Second)
 	}
+	return accepted
 }
 
-func getResults(batchID string, urlMap map[string]string) ([]OCRResult, error) {
-	apiKey := os.Getenv("ANTHROPIC_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("ANTHROPIC_API_KEY not set")
-	}
-
-	url := fmt.Sprintf("https://api.anthropic.com/v1/messages/batches/%s/results", batchID)
+func normalizeRecognizedName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	name = strings.Trim(name, " -–—•·*\"'.,:;()[]")
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("x-api-key", apiKey)
-	req.Header.Set("anthropic-version", "2023-06-01")
-
-	resp, err := (&http.Client{}).Do(req)
-	if err != nil {
-		return nil, err
+	lower := strings.ToLower(name)
+	for _, suffix := range []string{" logo", " logotype", " wordmark"} {
+		if strings.HasSuffix(lower, suffix) {
+			name = strings.TrimSpace(name[:len(name)-len(suffix)])
+			lower = strings.ToLower(name)
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	if len(name) < 2 || len(name) > maxNameLength || genericNames[lower] {
+		return ""
 	}
-
-	var results []OCRResult
-
-	scanner := bufio.NewScanner(resp.Body)
-	scanner.Buffer(make([]byte, 64*1024), 2*1024*1024)
-
-	for scanner.Scan() {
-		var batchResult BatchResult
-		if err := json.Unmarshal(scanner.Bytes(), &batchResult); err != nil {
-			continue
-		}
-
-		originalURL, exists := urlMap[batchResult.CustomID]
-		if !exists {
-			continue
-		}
-
-		if batchResult.Result.Type == "succeeded" {
-			var text string
-			for _, block := range batchResult.Result.Message.Content {
-				if block.Type == "text" {
-					text = block.Text
-					break
-				}
-			}
-			results = append(results, OCRResult{
-				ImageURL: originalURL,
-				Text:     text,
-			})
-		} else {
-			results = append(results, OCRResult{
-				ImageURL: originalURL,
-				Error:    fmt.Errorf("%s: %s", batchResult.Result.Error.Type, batchResult.Result.Error.Message),
-			})
-		}
+	if strings.IndexFunc(name, unicode.IsLetter) == -1 {
+		return ""
 	}
-
-	return results, scanner.Err()
+	return name
 }
 
 func getExtFromURL(url string) string {
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chandhuDev/JobLoop/internal/models"
+)
+
+func TestNormalizeRecognizedName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "Stripe", "Stripe"},
+		{"whitespace collapsed", "  Acme \n Robotics ", "Acme Robotics"},
+		{"punctuation trimmed", "\"Notion.\"", "Notion"},
+		{"logo suffix", "Airbnb logo", "Airbnb"},
+		{"wordmark suffix", "Figma Wordmark", "Figma"},
+		{"digits in name", "3M", "3M"},
+		{"generic word", "Customers", ""},
+		{"generic phrase", "Trusted by", ""},
+		{"too short", "X", ""},
+		{"no letters", "2024", ""},
+		{"too long", "This is a long tagline about building the future of work for everyone", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeRecognizedName(tt.in); got != tt.want {
+				t.Errorf("normalizeRecognizedName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAcceptedNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []models.RecognizedName
+		want  []string
+	}{
+		{
+			name:  "confident logo",
+			names: []models.RecognizedName{{Name: "Stripe", Confidence: 0.95, IsLogo: true}},
+			want:  []string{"Stripe"},
+		},
+		{
+			name: "low confidence and non-logo text dropped",
+			names: []models.RecognizedName{
+				{Name: "Build faster", Confidence: 0.9, IsLogo: false},
+				{Name: "Strpe", Confidence: 0.3, IsLogo: true},
+				{Name: "Notion", Confidence: minNameConfidence, IsLogo: true},
+			},
+			want: []string{"Notion"},
+		},
+		{
+			name: "several names in one entry",
+			names: []models.RecognizedName{
+				{Name: "Stripe | Notion; Figma\nLinear · Vercel", Confidence: 0.9, IsLogo: true},
+			},
+			want: []string{"Stripe", "Notion", "Figma", "Linear", "Vercel"},
+		},
+		{
+			name:  "generic parts dropped",
+			names: []models.RecognizedName{{Name: "Logo | Ramp logo", Confidence: 0.9, IsLogo: true}},
+			want:  []string{"Ramp"},
+		},
+		{
+			name: "nothing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := acceptedNames(tt.names); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("acceptedNames = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+/* ================= FAKE PROVIDER ================= */
+
+func TestFakeVisionRecognize(t *testing.T) {
+	fake := &FakeVision{Names: map[string][]string{
+		"5f2b9c":                          {"Stripe"},
+		"https://acme.com/logos/wall.png": {"Notion", "Figma"},
+		"linear.svg":                      {"Linear"},
+	}}
+
+	requests := []models.OCRRequest{
+		{CustomID: "by-hash", ContentHash: "5f2b9c", ImageURL: "https://acme.com/a.png"},
+		{CustomID: "by-url", ImageURL: "https://acme.com/logos/wall.png"},
+		{CustomID: "by-file", ImageURL: "https://cdn.acme.com/img/linear.svg?v=3"},
+		{CustomID: "unknown", ImageURL: "https://acme.com/hero.jpg"},
+	}
+
+	results, err := fake.Recognize(context.Background(), requests)
+	if err != nil {
+		t.Fatalf("Recognize: %v", err)
+	}
+	if len(results) != len(requests) {
+		t.Fatalf("got %d results for %d requests", len(results), len(requests))
+	}
+
+	want := map[string][]string{
+		"by-hash": {"Stripe"},
+		"by-url":  {"Notion", "Figma"},
+		"by-file": {"Linear"},
+		"unknown": nil,
+	}
+	for _, result := range results {
+		if got := acceptedNames(result.Names); !reflect.DeepEqual(got, want[result.CustomID]) {
+			t.Errorf("%s: names = %q, want %q", result.CustomID, got, want[result.CustomID])
+		}
+		if result.Names == nil {
+			t.Errorf("%s: Names is nil, want an empty list for images with no names", result.CustomID)
+		}
+	}
+}
+
+func TestRecognizeImagesWithFakeVision(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vision.json")
+	fixtures := `{"stripe.png": ["Stripe"], "wall.png": ["Notion | Figma", "Customers"]}`
+	if err := os.WriteFile(path, []byte(fixtures), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fake, err := LoadFakeVision(path)
+	if err != nil {
+		t.Fatalf("LoadFakeVision: %v", err)
+	}
+
+	v := &VisionWrapper{Provider: fake}
+	images := []*ocrImage{
+		{URL: "https://acme.com/stripe.png", ContentHash: "a1", MediaType: "image/png"},
+		{URL: "https://acme.com/wall.png", ContentHash: "b2", MediaType: "image/png"},
+		{URL: "https://acme.com/team.png", ContentHash: "c3", MediaType: "image/png"},
+	}
+
+	got := v.recognizeImages(context.Background(), images, nil, 1)
+	want := []string{"Stripe", "Notion", "Figma"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("recognizeImages = %q, want %q", got, want)
+	}
+}
+
+func TestLoadFakeVision(t *testing.T) {
+	empty, err := LoadFakeVision("")
+	if err != nil || empty == nil {
+		t.Fatalf("LoadFakeVision(\"\") = %v, %v; want a fake that recognises nothing", empty, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "broken.json")
+	if err := os.WriteFile(path, []byte(`["not", "an", "object"]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFakeVision(path); err == nil {
+		t.Error("LoadFakeVision of a JSON array succeeded, want an error")
+	}
+}
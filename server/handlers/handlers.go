@@ -2,20 +2,26 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
+	"github.com/chandhuDev/JobLoop/internal/repository"
 	"github.com/chandhuDev/JobLoop/internal/schema"
+	"github.com/chandhuDev/JobLoop/server/middleware"
 	"gorm.io/gorm"
 )
 
 type Handlers struct {
-	DB *gorm.DB
+	DB         *gorm.DB
+	AdminToken string
 }
 
-func NewHandlers(db *gorm.DB) *Handlers {
-	return &Handlers{DB: db}
+func NewHandlers(db *gorm.DB, adminToken string) *Handlers {
+	return &Handlers{DB: db, AdminToken: adminToken}
 }
 
 func (h *Handlers) RegisterRoutes(mux *http.ServeMux) {
@@ -26,6 +32,16 @@ func (h *Handlers) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/jobs", h.getJobs)
 
 	mux.HandleFunc("GET /api/state", h.getDBStats)
+
+	mux.HandleFunc("POST /api/companies/merge", middleware.RequireAdminToken(h.AdminToken, h.mergeCompanies))
+
+	mux.HandleFunc("GET /api/resolutions", h.getResolutions)
+
+	mux.HandleFunc("PUT /api/resolutions/{id}", middleware.RequireAdminToken(h.AdminToken, h.updateResolution))
+
+	mux.HandleFunc("DELETE /api/resolutions/{id}", middleware.RequireAdminToken(h.AdminToken, h.deleteResolution))
+
+	mux.HandleFunc("GET /api/spend", h.getSpend)
 }
 
 func (h *Handlers) healthCheck(w http.ResponseWriter, r *http.Request) {
@@ -50,14 +66,21 @@ func (h *Handlers) getCompanies(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	query := h.DB.Model(&schema.SeedCompany{})
+
+	// Companies whose site redirects to another domain, for review
+	if r.URL.Query().Get("redirected") == "true" {
+		query = query.Where("redirects_to <> ''")
+	}
+
 	var companies []schema.SeedCompany
-	result := h.DB.Order("id ASC").Limit(limit).Offset(offset).Find(&companies)
+	result := query.Order("id ASC").Limit(limit).Offset(offset).Find(&companies)
 	if result.Error != nil {
 		h.errorResponse(w, http.StatusInternalServerError, "Failed to fetch companies")
 		return
 	}
 	var total int64
-	h.DB.Model(&schema.SeedCompany{}).Count(&total)
+	query.Count(&total)
 
 	h.jsonResponse(w, http.StatusOK, map[string]interface{}{
 		"data":   companies,
@@ -147,6 +170,214 @@ func (h *Handlers) getDBStats(w http.ResponseWriter, r *http.Request) {
 	h.jsonResponse(w, http.StatusOK, stats)
 }
 
+// mergeCompanies moves the duplicate's jobs, testimonials and failures onto
+// the survivor and deletes the duplicate.
+func (h *Handlers) mergeCompanies(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		SurvivorID  uint `json:"survivor_id"`
+		DuplicateID uint `json:"duplicate_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.SurvivorID == 0 || body.DuplicateID == 0 {
+		h.errorResponse(w, http.StatusBadRequest, "survivor_id and duplicate_id are required")
+		return
+	}
+
+	result, err := repository.MergeSeedCompanies(h.DB, body.SurvivorID, body.DuplicateID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		h.errorResponse(w, http.StatusNotFound, "Company not found")
+		return
+	}
+	if err != nil {
+		h.errorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.jsonResponse(w, http.StatusOK, result)
+}
+
+// getResolutions lists cached name-to-website mappings for review, least
+// confident first. max_confidence and negative narrow the list.
+func (h *Handlers) getResolutions(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	offset := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := parseInt(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := parseInt(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	query := h.DB.Model(&schema.CompanyResolution{})
+
+	if mc := r.URL.Query().Get("max_confidence"); mc != "" {
+		if parsed, err := strconv.ParseFloat(mc, 64); err == nil {
+			query = query.Where("confidence <= ? AND negative = ?", parsed, false)
+		}
+	}
+	switch r.URL.Query().Get("negative") {
+	case "true":
+		query = query.Where("negative = ?", true)
+	case "false":
+		query = query.Where("negative = ?", false)
+	}
+	if r.URL.Query().Get("reviewed") == "false" {
+		query = query.Where("reviewed = ?", false)
+	}
+
+	var total int64
+	query.Count(&total)
+
+	var resolutions []schema.CompanyResolution
+	result := query.Order("confidence ASC, id ASC").Limit(limit).Offset(offset).Find(&resolutions)
+	if result.Error != nil {
+		h.errorResponse(w, http.StatusInternalServerError, "Failed to fetch resolutions")
+		return
+	}
+
+	h.jsonResponse(w, http.StatusOK, map[string]interface{}{
+		"data":   resolutions,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// updateResolution records a manual correction. Corrected rows never expire
+// and are not overwritten by later automatic lookups.
+func (h *Handlers) updateResolution(w http.ResponseWriter, r *http.Request) {
+	id, err := parseInt(r.PathValue("id"))
+	if err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	var body struct {
+		CompanyURL string `json:"company_url"`
+		Negative   bool   `json:"negative"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !body.Negative {
+		parsed, err := url.Parse(body.CompanyURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			h.errorResponse(w, http.StatusBadRequest, "company_url must be an absolute http(s) URL")
+			return
+		}
+	} else {
+		body.CompanyURL = ""
+	}
+
+	var resolution schema.CompanyResolution
+	if err := h.DB.First(&resolution, id).Error; err != nil {
+		h.errorResponse(w, http.StatusNotFound, "Resolution not found")
+		return
+	}
+
+	resolution.CompanyURL = body.CompanyURL
+	resolution.Negative = body.Negative
+	resolution.Confidence = 1
+	resolution.Source = "manual"
+	resolution.Reviewed = true
+	resolution.ResolvedAt = time.Now()
+	resolution.ExpiresAt = nil
+
+	if err := h.DB.Save(&resolution).Error; err != nil {
+		h.errorResponse(w, http.StatusInternalServerError, "Failed to update resolution")
+		return
+	}
+
+	h.jsonResponse(w, http.StatusOK, resolution)
+}
+
+// deleteResolution drops a mapping so the next lookup resolves it again.
+func (h *Handlers) deleteResolution(w http.ResponseWriter, r *http.Request) {
+	id, err := parseInt(r.PathValue("id"))
+	if err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	result := h.DB.Delete(&schema.CompanyResolution{}, id)
+	if result.Error != nil {
+		h.errorResponse(w, http.StatusInternalServerError, "Failed to delete resolution")
+		return
+	}
+	if result.RowsAffected == 0 {
+		h.errorResponse(w, http.StatusNotFound, "Resolution not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getSpend summarises recorded Anthropic usage over the last `days` days
+// (default 30) by day, stage and run. run_id narrows it to one run.
+func (h *Handlers) getSpend(w http.ResponseWriter, r *http.Request) {
+	days := 30
+	if d := r.URL.Query().Get("days"); d != "" {
+		if parsed, err := parseInt(d); err == nil && parsed > 0 && parsed <= 365 {
+			days = parsed
+		}
+	}
+
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+	base := func() *gorm.DB {
+		query := h.DB.Model(&schema.LLMUsage{}).Where("created_at >= ?", since)
+		if runID := r.URL.Query().Get("run_id"); runID != "" {
+			query = query.Where("run_id = ?", runID)
+		}
+		return query
+	}
+
+	type spendRow struct {
+		Key          string  `json:"key"`
+		Calls        int64   `json:"calls"`
+		InputTokens  int64   `json:"input_tokens"`
+		OutputTokens int64   `json:"output_tokens"`
+		WebSearches  int64   `json:"web_searches"`
+		CostUSD      float64 `json:"cost_usd"`
+	}
+	totals := "count(*) AS calls, COALESCE(SUM(input_tokens), 0) AS input_tokens, COALESCE(SUM(output_tokens), 0) AS output_tokens, " +
+		"COALESCE(SUM(web_search_requests), 0) AS web_searches, COALESCE(SUM(cost_usd), 0) AS cost_usd"
+
+	var total spendRow
+	if err := base().Select(totals).Scan(&total).Error; err != nil {
+		h.errorResponse(w, http.StatusInternalServerError, "Failed to fetch spend")
+		return
+	}
+
+	var byDay, byStage, byRun []spendRow
+	err := base().Select("to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS key, " + totals).
+		Group("key").Order("key DESC").Scan(&byDay).Error
+	if err == nil {
+		err = base().Select("stage AS key, " + totals).Group("stage").Order("cost_usd DESC").Scan(&byStage).Error
+	}
+	if err == nil {
+		err = base().Select("run_id AS key, " + totals).Group("run_id").Order("MAX(created_at) DESC").Limit(20).Scan(&byRun).Error
+	}
+	if err != nil {
+		h.errorResponse(w, http.StatusInternalServerError, "Failed to fetch spend")
+		return
+	}
+
+	total.Key = "total"
+	h.jsonResponse(w, http.StatusOK, map[string]interface{}{
+		"since":    since.Format("2006-01-02"),
+		"total":    total,
+		"by_day":   byDay,
+		"by_stage": byStage,
+		"by_run":   byRun,
+	})
+}
+
 func (h *Handlers) jsonResponse(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
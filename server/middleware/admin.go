@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// RequireAdminToken guards write endpoints with a static bearer token. When
+// no token is configured the endpoints are disabled rather than left open.
+func RequireAdminToken(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			adminError(w, http.StatusForbidden, "Admin API disabled")
+			return
+		}
+
+		provided := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			adminError(w, http.StatusUnauthorized, "Invalid admin token")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func adminError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -20,8 +20,8 @@ func DefaultCORSConfig() CORSConfig {
 			"http://localhost:3000",
 			"https://jobloop.chandhu.dev",
 		},
-		AllowedMethods:   []string{"GET"},
-		AllowedHeaders:   []string{"Accept", "Content-Type", "X-CSRF-Token"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
 		ExposedHeaders:   []string{},
 		AllowCredentials: false,
 		MaxAge:           3600,